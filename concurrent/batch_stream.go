@@ -0,0 +1,56 @@
+package concurrent
+
+import "time"
+
+// BatchStream groups the values received from the input channel into batches sent to the returned channel. A batch is
+// sent once it holds maxSize values, or once maxWait has elapsed since its first value was received, whichever comes
+// first, and the last partial batch is sent when the input channel is closed, right before the returned channel is
+// closed. A maxSize that is zero or negative is treated as one, and a maxWait that is zero or negative only flushes the
+// batches when they are full or when the input channel is closed.
+func BatchStream[T any](input <-chan T, maxSize int, maxWait time.Duration) <-chan []T {
+	if maxSize <= 0 {
+		maxSize = 1
+	}
+
+	outputChannel := make(chan []T)
+	go func() {
+		defer close(outputChannel)
+
+		batch := make([]T, 0, maxSize)
+		var timer *time.Timer
+		// nil while the batch is empty, so the select does not wait on it
+		var timeout <-chan time.Time
+		flush := func() {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+			outputChannel <- batch
+			batch = make([]T, 0, maxSize)
+		}
+
+		for {
+			select {
+			case v, ok := <-input:
+				if !ok {
+					if len(batch) > 0 {
+						flush()
+					}
+					return
+				}
+				batch = append(batch, v)
+				if len(batch) == 1 && maxWait > 0 {
+					timer = time.NewTimer(maxWait)
+					timeout = timer.C
+				}
+				if len(batch) == maxSize {
+					flush()
+				}
+			case <-timeout:
+				flush()
+			}
+		}
+	}()
+
+	return outputChannel
+}
@@ -0,0 +1,49 @@
+package concurrent_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchStream(t *testing.T) {
+	t.Run("full batches are sent right away", func(t *testing.T) {
+		batches := drain(BatchStream(generate(1, 2, 3, 4, 5, 6), 3, time.Hour))
+		assert.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}}, batches)
+	})
+
+	t.Run("a partial batch is sent after maxWait", func(t *testing.T) {
+		input := make(chan int)
+		batches := BatchStream(input, 10, 20*time.Millisecond)
+
+		start := time.Now()
+		input <- 1
+		input <- 2
+		assert.Equal(t, []int{1, 2}, <-batches)
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+		// the wait starts over with the first value of the next batch
+		input <- 3
+		assert.Equal(t, []int{3}, <-batches)
+
+		close(input)
+		_, ok := <-batches
+		assert.False(t, ok)
+	})
+
+	t.Run("the last partial batch is sent on close", func(t *testing.T) {
+		batches := drain(BatchStream(generate(1, 2, 3, 4, 5), 2, 0))
+		assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, batches)
+	})
+
+	t.Run("empty input sends no batch", func(t *testing.T) {
+		assert.Empty(t, drain(BatchStream(generate[int](), 2, time.Millisecond)))
+	})
+
+	t.Run("non-positive maxSize sends every value on its own", func(t *testing.T) {
+		batches := drain(BatchStream(generate(1, 2), 0, time.Hour))
+		assert.Equal(t, [][]int{{1}, {2}}, batches)
+	})
+}
@@ -0,0 +1,24 @@
+package concurrent
+
+// Chunk splits items into consecutive sub-slices of size items, except the last one which holds whatever items remain,
+// e.g. to hand batches of inputs to Execute. A size that is zero or negative does not split items at all, i.e. it
+// returns a single chunk holding all of them, and an empty items slice has no chunk. The chunks share the backing array
+// of items, but with their capacity capped to their length, so appending to a chunk never overwrites the next one.
+func Chunk[T any](items []T, size int) [][]T {
+	if len(items) == 0 {
+		return [][]T{}
+	}
+	if size <= 0 || size > len(items) {
+		size = len(items)
+	}
+
+	chunks := make([][]T, 0, (len(items)+size-1)/size)
+	for start := 0; start < len(items); start += size {
+		end := start + size
+		if end > len(items) {
+			end = len(items)
+		}
+		chunks = append(chunks, items[start:end:end])
+	}
+	return chunks
+}
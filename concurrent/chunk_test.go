@@ -0,0 +1,60 @@
+package concurrent_test
+
+import (
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunk(t *testing.T) {
+	testCases := []struct {
+		name     string
+		items    []int
+		size     int
+		expected [][]int
+	}{
+		{
+			name:     "exact division",
+			items:    []int{1, 2, 3, 4, 5, 6},
+			size:     3,
+			expected: [][]int{{1, 2, 3}, {4, 5, 6}},
+		},
+		{
+			name:     "the last chunk holds the remainder",
+			items:    []int{1, 2, 3, 4, 5},
+			size:     2,
+			expected: [][]int{{1, 2}, {3, 4}, {5}},
+		},
+		{
+			name:     "empty items",
+			items:    []int{},
+			size:     2,
+			expected: [][]int{},
+		},
+		{
+			name:     "size larger than the items",
+			items:    []int{1, 2, 3},
+			size:     10,
+			expected: [][]int{{1, 2, 3}},
+		},
+		{
+			name:     "non-positive size",
+			items:    []int{1, 2, 3},
+			size:     0,
+			expected: [][]int{{1, 2, 3}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Chunk(tc.items, tc.size))
+		})
+	}
+
+	t.Run("appending to a chunk does not overwrite the next one", func(t *testing.T) {
+		chunks := Chunk([]int{1, 2, 3, 4}, 2)
+		_ = append(chunks[0], 99)
+		assert.Equal(t, []int{3, 4}, chunks[1])
+	})
+}
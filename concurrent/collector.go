@@ -0,0 +1,28 @@
+package concurrent
+
+import "sync"
+
+// Collector gathers values added by multiple goroutines into a single slice, for the goroutine topologies that are
+// built by hand rather than with Execute. The zero value is an empty Collector ready to use, and a Collector must not
+// be copied once used.
+type Collector[T any] struct {
+	mu     sync.Mutex
+	values []T
+}
+
+// Add appends v to the values of the collector. It could be called concurrently from multiple goroutines.
+func (c *Collector[T]) Add(v T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values = append(c.values, v)
+}
+
+// Result returns a copy of the values added so far, in the order the calls to Add were serialized in. It could be
+// called while values are still being added, in which case it returns the values added up to that point.
+func (c *Collector[T]) Result() []T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	values := make([]T, len(c.values))
+	copy(values, c.values)
+	return values
+}
@@ -0,0 +1,49 @@
+package concurrent_test
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollector(t *testing.T) {
+	t.Run("no value is lost or duplicated under concurrent adds", func(t *testing.T) {
+		const goroutines, perGoroutine = 50, 200
+		var c Collector[int]
+
+		var wg sync.WaitGroup
+		wg.Add(goroutines)
+		for g := 0; g < goroutines; g++ {
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < perGoroutine; i++ {
+					c.Add(g*perGoroutine + i)
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		expected := make([]int, goroutines*perGoroutine)
+		for i := range expected {
+			expected[i] = i
+		}
+		assert.ElementsMatch(t, expected, c.Result())
+	})
+
+	t.Run("the result is a copy", func(t *testing.T) {
+		var c Collector[string]
+		c.Add("a")
+		result := c.Result()
+		result[0] = "b"
+		c.Add("c")
+		assert.Equal(t, []string{"a", "c"}, c.Result())
+	})
+
+	t.Run("empty collector", func(t *testing.T) {
+		var c Collector[int]
+		assert.NotNil(t, c.Result())
+		assert.Empty(t, c.Result())
+	})
+}
@@ -1,54 +1,281 @@
 package concurrent
 
-import "sync"
+import (
+	"context"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"time"
+)
+
+// DefaultRoutines is the number of goroutines used when numOfRoutines is zero or negative. It is runtime.NumCPU()
+// unless overridden, which should be done once at startup, before any function of this package is called. A
+// DefaultRoutines that is zero or negative falls back to runtime.NumCPU().
+var DefaultRoutines = runtime.NumCPU()
+
+// Sequential, when set, makes every function of this package process its inputs one at a time, on a single worker
+// and in the order of the inputs, while keeping the same API and behavior otherwise. The one exception is
+// ExecuteWithTimeout, whose worker still moves on from a process call that timed out, so that call may overlap with the
+// next ones until it returns. Sequential is meant for tests, to make the code calling this package deterministic, and
+// to tell whether a bug lies in the process function or in its concurrent execution, never for production. Like
+// DefaultRoutines, it should only be set before any function of this package is called, e.g. in TestMain.
+var Sequential = false
+
+// MaxRoutines is the upper bound of the number of goroutines spawned for a single call, beyond which numOfRoutines,
+// like every other goroutine count accepted by this package, is clamped to MaxRoutines. It guards against a
+// misconfigured or untrusted count that would otherwise spawn millions of goroutines and allocate buffers to match,
+// which could crash the program. Like DefaultRoutines, it could be overridden once at startup, and a MaxRoutines that
+// is zero or negative disables the bound.
+var MaxRoutines = 10000
 
 // Execute will process all inputs concurrently by calling the function passed in the arguments.
 // The number of goroutines that are used in the concurrent execution could be specified in the numOfRoutines parameter.
 // The execution follows fan-out and then fan-in pattern, in which multiple processes are run concurrently, then each
 // outputs are gathered and appended to a single slice at the end of the execution. The slice is not guaranteed to have
-// the one-to-one order as the input, so it is advised to not rely on the output slice order.
+// the one-to-one order as the input, so it is advised to not rely on the output slice order. Use ExecuteOrdered or
+// ExecuteSortedBy instead when a deterministic output slice is needed.
+// A numOfRoutines that is zero or negative defaults to DefaultRoutines, one that is above MaxRoutines is clamped to it,
+// and no more goroutines than the number of inputs are spawned, so an empty inputs slice spawns none at all. The same
+// applies to every other function in this package that accepts numOfRoutines.
+// A nil process panics with ErrNilProcess, unless inputs is empty. The functions of this package that return an error
+// return ErrNilProcess instead.
 func Execute[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) TypeOut) []TypeOut {
-	inputChannels := make([](chan TypeIn), numOfRoutines)
-	outputChannel := make(chan TypeOut)
-	for i := 0; i < numOfRoutines; i++ {
-		inputChannels[i] = make(chan TypeIn)
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
 	}
 
-	var wg sync.WaitGroup
-	wg.Add(numOfRoutines)
+	outputChannel := fanOut(context.Background(), numOfRoutines, inputs, func(_, _ int, input TypeIn) TypeOut {
+		return process(input)
+	})
 
-	// spawn workers
-	for i := 0; i < numOfRoutines; i++ {
-		inputChannel := inputChannels[i]
-		go func(inputChan chan TypeIn, outputChan chan TypeOut) {
-			defer wg.Done()
-			for input := range inputChan {
-				outputChan <- process(input)
-			}
-		}(inputChannel, outputChannel)
+	// wait for outputs
+	outputs := make([]TypeOut, 0, len(inputs))
+	for o := range outputChannel {
+		outputs = append(outputs, o)
 	}
 
-	// distribute inputs
-	go func(inputs []TypeIn, inputChannels [](chan TypeIn)) {
-		for i, input := range inputs {
-			channel := i % numOfRoutines
-			inputChannels[channel] <- input
+	return outputs
+}
+
+// fanOut distributes the inputs to the workers with the default fanOutConfig, see fanOutWith.
+func fanOut[TypeIn any, TypeOut any](ctx context.Context, numOfRoutines int, inputs []TypeIn, work func(worker, index int, input TypeIn) TypeOut) <-chan TypeOut {
+	return fanOutWith(ctx, numOfRoutines, inputs, fanOutConfig{}, work)
+}
+
+// fanOutConfig tunes how fanOutWith distributes the inputs and gathers the outputs. Its zero value is the default.
+type fanOutConfig struct {
+	// bufferSize is the capacity of the output channel, which is the number of workers when zero, and no capacity at
+	// all when negative.
+	bufferSize int
+	// onWorkerStart and onWorkerExit, when not nil, are called by every worker with its id, right after the worker is
+	// spawned and right before it exits. A worker for which onWorkerStart returns false exits right away, without
+	// processing any input nor calling onWorkerExit.
+	onWorkerStart func(worker int) bool
+	onWorkerExit  func(worker int)
+	// assign, when not nil, assigns the inputs to the workers up front instead of letting the workers pick them from a
+	// shared queue. It is called with the number of workers, and returns the indexes of the inputs of every worker, in
+	// the order the worker should process them.
+	assign func(numOfWorkers int) [][]int
+	// minInterval, when positive, is the minimum duration between handing an input to a worker and handing the next
+	// one to any worker.
+	minInterval time.Duration
+	// dropOnDone, when set, makes the workers drop their outputs and exit once ctx is done, instead of waiting for the
+	// caller to receive them, for the callers that may stop receiving from the returned channel.
+	dropOnDone bool
+	// label, when not empty, is set as the pprof label of every worker goroutine, see Options.Label.
+	label string
+}
+
+// fanOutWith distributes the inputs to numOfRoutines workers which call work for every input they receive, together
+// with the index of that input and the id of the worker, ranging from zero to the number of spawned workers as returned
+// by workerCount. Whatever work returns is sent to the returned channel, which is closed once every worker has
+// finished, so the caller must keep receiving from it until it is closed. Once ctx is done, no more inputs are
+// distributed, and the workers exit as soon as the inputs they have already received are processed. The functions that
+// stop early must do so by canceling ctx and still draining the channel, rather than giving up on it, so that no worker
+// is left blocked on a send.
+func fanOutWith[TypeIn any, TypeOut any](ctx context.Context, numOfRoutines int, inputs []TypeIn, config fanOutConfig, work func(worker, index int, input TypeIn) TypeOut) <-chan TypeOut {
+	numOfRoutines = workerCount(numOfRoutines, len(inputs))
+	if Sequential {
+		// the assignments may not follow the order of the inputs
+		config.assign = nil
+	}
+	bufferSize := config.bufferSize
+	if bufferSize == 0 {
+		bufferSize = numOfRoutines
+	} else if bufferSize < 0 {
+		bufferSize = 0
+	}
+	outputChannel := make(chan TypeOut, bufferSize)
+	if numOfRoutines == 0 {
+		close(outputChannel)
+		return outputChannel
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(numOfRoutines)
+	workersDone := make(chan struct{})
+
+	if config.assign != nil {
+		assigned := config.assign(numOfRoutines)
+		// without a distributor, the workers take turns through the limiter instead
+		limiter := newIntervalLimiter(config.minInterval)
+		for i := 0; i < numOfRoutines; i++ {
+			go func(worker int) {
+				defer wg.Done()
+				runWorker(worker, config, func() {
+					for _, index := range assigned[worker] {
+						if !limiter.waitContext(ctx) {
+							return
+						}
+						if !send(ctx, config, outputChannel, work(worker, index, inputs[index])) {
+							return
+						}
+					}
+				})
+			}(i)
 		}
-		for _, inputChan := range inputChannels {
-			close(inputChan)
+	} else {
+		// all workers share the same input channel, so a worker picks up the next input as soon as it is free
+		inputChannel := make(chan int)
+
+		// spawn workers
+		for i := 0; i < numOfRoutines; i++ {
+			go func(worker int) {
+				defer wg.Done()
+				runWorker(worker, config, func() {
+					for index := range inputChannel {
+						if !send(ctx, config, outputChannel, work(worker, index, inputs[index])) {
+							return
+						}
+					}
+				})
+			}(i)
 		}
-	}(inputs, inputChannels)
+
+		// distribute inputs, until every worker has exited in case they all exit early
+		go func() {
+			defer close(inputChannel)
+			var lastDispatch time.Time
+			for i := range inputs {
+				if config.minInterval > 0 && i > 0 {
+					// a timer rather than a sleep, so that a cancellation does not wait for the interval to elapse
+					timer := time.NewTimer(time.Until(lastDispatch.Add(config.minInterval)))
+					select {
+					case <-timer.C:
+					case <-ctx.Done():
+						timer.Stop()
+						return
+					case <-workersDone:
+						timer.Stop()
+						return
+					}
+				}
+				// checked on its own first, since select picks at random when a worker is also ready
+				if ctx.Err() != nil {
+					return
+				}
+				select {
+				case inputChannel <- i:
+					lastDispatch = time.Now()
+				case <-ctx.Done():
+					return
+				case <-workersDone:
+					return
+				}
+			}
+		}()
+	}
 
 	go func() {
 		wg.Wait()
+		close(workersDone)
 		close(outputChannel)
 	}()
 
-	// wait for outputs
-	outputs := []TypeOut{}
-	for o := range outputChannel {
-		outputs = append(outputs, o)
+	return outputChannel
+}
+
+// send sends the output to the output channel, and reports whether the worker should go on. With dropOnDone, the
+// output is dropped when ctx is done before it could be sent.
+func send[TypeOut any](ctx context.Context, config fanOutConfig, outputChannel chan<- TypeOut, output TypeOut) bool {
+	if !config.dropOnDone {
+		outputChannel <- output
+		return true
 	}
+	select {
+	case outputChannel <- output:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
 
-	return outputs
+// assignRoundRobin assigns the indexes of numOfInputs inputs to numOfWorkers workers, the index i going to the worker
+// i modulo numOfWorkers.
+func assignRoundRobin(numOfWorkers int, numOfInputs int) [][]int {
+	assigned := make([][]int, numOfWorkers)
+	for i := 0; i < numOfInputs; i++ {
+		assigned[i%numOfWorkers] = append(assigned[i%numOfWorkers], i)
+	}
+	return assigned
+}
+
+// runWorker runs the loop of a worker, surrounded by the hooks of the config, with the pprof label of the config.
+func runWorker(worker int, config fanOutConfig, loop func()) {
+	if config.label == "" {
+		runHooked(worker, config, loop)
+		return
+	}
+	pprof.Do(context.Background(), pprof.Labels(labelKey, config.label), func(context.Context) {
+		runHooked(worker, config, loop)
+	})
+}
+
+// runHooked runs the loop of a worker, surrounded by the hooks of the config.
+func runHooked(worker int, config fanOutConfig, loop func()) {
+	if config.onWorkerStart != nil && !config.onWorkerStart(worker) {
+		return
+	}
+	if config.onWorkerExit != nil {
+		defer config.onWorkerExit(worker)
+	}
+	loop()
+}
+
+// workerCount returns the number of workers to spawn for the requested numOfRoutines over numOfInputs inputs.
+func workerCount(numOfRoutines int, numOfInputs int) int {
+	numOfRoutines = routinesOrDefault(numOfRoutines)
+	if numOfRoutines > numOfInputs {
+		return numOfInputs
+	}
+	return numOfRoutines
+}
+
+// routinesOrDefault returns the number of workers to spawn for the requested numOfRoutines, which is one when
+// Sequential is set, see positiveOrDefault otherwise, clamped to MaxRoutines.
+func routinesOrDefault(numOfRoutines int) int {
+	if Sequential {
+		return 1
+	}
+	return clampRoutines(positiveOrDefault(numOfRoutines))
+}
+
+// positiveOrDefault returns numOfRoutines, or DefaultRoutines when numOfRoutines is not positive.
+func positiveOrDefault(numOfRoutines int) int {
+	if numOfRoutines > 0 {
+		return numOfRoutines
+	}
+	if DefaultRoutines > 0 {
+		return DefaultRoutines
+	}
+	return runtime.NumCPU()
+}
+
+// clampRoutines returns numOfRoutines, or MaxRoutines when it is positive and numOfRoutines is above it. It only
+// applies to the counts of goroutines to spawn, not to the other counts such as the slots of a Semaphore.
+func clampRoutines(numOfRoutines int) int {
+	if MaxRoutines > 0 && numOfRoutines > MaxRoutines {
+		return MaxRoutines
+	}
+	return numOfRoutines
 }
@@ -1,9 +1,14 @@
 package concurrent_test
 
 import (
+	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"math/big"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -24,6 +29,30 @@ type testOutput struct {
 	err   error
 }
 
+// peakCounter tracks how many goroutines are inside a section at the same time, and the highest number seen.
+type peakCounter struct {
+	running int64
+	peak    int64
+}
+
+func (c *peakCounter) enter() {
+	n := atomic.AddInt64(&c.running, 1)
+	for {
+		p := atomic.LoadInt64(&c.peak)
+		if n <= p || atomic.CompareAndSwapInt64(&c.peak, p, n) {
+			return
+		}
+	}
+}
+
+func (c *peakCounter) exit() {
+	atomic.AddInt64(&c.running, -1)
+}
+
+func (c *peakCounter) max() int64 {
+	return atomic.LoadInt64(&c.peak)
+}
+
 func sleepForARandomTime() {
 	random, err := rand.Int(rand.Reader, big.NewInt(100))
 	if err != nil {
@@ -119,3 +148,439 @@ func TestExecute(t *testing.T) {
 		})
 	}
 }
+
+func TestExecuteNonPositiveNumOfRoutines(t *testing.T) {
+	inputs := make([]int, 50)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	testCases := []struct {
+		name          string
+		numOfRoutines int
+	}{
+		{
+			name:          "zero number of routines",
+			numOfRoutines: 0,
+		},
+		{
+			name:          "negative number of routines",
+			numOfRoutines: -1,
+		},
+		{
+			name:          "single routine",
+			numOfRoutines: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			processed := map[int]int{}
+			outputs := Execute(tc.numOfRoutines, inputs, func(in int) int {
+				mu.Lock()
+				defer mu.Unlock()
+				processed[in]++
+				return in
+			})
+
+			assert.ElementsMatch(t, inputs, outputs)
+			for _, in := range inputs {
+				assert.Equal(t, 1, processed[in])
+			}
+		})
+	}
+}
+
+func TestMaxRoutines(t *testing.T) {
+	maxRoutines := MaxRoutines
+	defer func() {
+		MaxRoutines = maxRoutines
+	}()
+
+	inputs := make([]time.Duration, 50)
+	for i := range inputs {
+		inputs[i] = 5 * time.Millisecond
+	}
+
+	t.Run("a huge numOfRoutines is clamped", func(t *testing.T) {
+		MaxRoutines = 4
+		goroutinesBefore := runtime.NumGoroutine()
+		var counter peakCounter
+		var peakGoroutines int64
+		outputs := Execute(1000000000, inputs, func(in time.Duration) time.Duration {
+			counter.enter()
+			defer counter.exit()
+			if n := int64(runtime.NumGoroutine()); n > atomic.LoadInt64(&peakGoroutines) {
+				atomic.StoreInt64(&peakGoroutines, n)
+			}
+			return sleepFor(in)
+		})
+		assert.Len(t, outputs, len(inputs))
+		assert.Equal(t, int64(MaxRoutines), counter.max())
+		// the workers, the distributor and the goroutine closing the output channel
+		assert.LessOrEqual(t, atomic.LoadInt64(&peakGoroutines), int64(goroutinesBefore+MaxRoutines+2))
+	})
+
+	t.Run("a non-positive MaxRoutines disables the bound", func(t *testing.T) {
+		MaxRoutines = 0
+		var counter peakCounter
+		Execute(len(inputs), inputs, func(in time.Duration) time.Duration {
+			counter.enter()
+			defer counter.exit()
+			return sleepFor(in)
+		})
+		assert.Greater(t, counter.max(), int64(4))
+	})
+}
+
+func TestExecuteEmptyInputs(t *testing.T) {
+	outputs := Execute(4, []testInput{}, testProcess)
+	assert.NotNil(t, outputs)
+	assert.Empty(t, outputs)
+
+	t.Run("no goroutine is spawned", func(t *testing.T) {
+		// compared with LessOrEqual, since the goroutines left by the other tests could exit meanwhile
+		goroutinesBefore := runtime.NumGoroutine()
+		outputChannel := ExecuteAsync(context.Background(), 4, []int{}, func(in int) int {
+			return in
+		})
+		goroutinesDuring := runtime.NumGoroutine()
+		_, ok := <-outputChannel
+
+		assert.False(t, ok)
+		assert.LessOrEqual(t, goroutinesDuring, goroutinesBefore)
+		assert.Empty(t, Execute(4, []int{}, func(in int) int {
+			return in
+		}))
+		assert.LessOrEqual(t, runtime.NumGoroutine(), goroutinesBefore)
+	})
+}
+
+func TestNilProcess(t *testing.T) {
+	inputs := []int{1, 2, 3}
+
+	testCases := []struct {
+		name    string
+		execute func(inputs []int)
+	}{
+		{
+			name: "Execute",
+			execute: func(inputs []int) {
+				Execute[int, int](4, inputs, nil)
+			},
+		},
+		{
+			name: "ExecuteOrdered",
+			execute: func(inputs []int) {
+				ExecuteOrdered[int, int](4, inputs, nil)
+			},
+		},
+		{
+			name: "ExecuteFilter",
+			execute: func(inputs []int) {
+				ExecuteFilter(4, inputs, nil)
+			},
+		},
+		{
+			name: "ExecuteReduce",
+			execute: func(inputs []int) {
+				ExecuteReduce(4, inputs, 0, nil, func(a, b int) int { return a + b })
+			},
+		},
+		{
+			name: "ForEach",
+			execute: func(inputs []int) {
+				ForEach(4, inputs, nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.PanicsWithValue(t, ErrNilProcess, func() { tc.execute(inputs) })
+			assert.NotPanics(t, func() { tc.execute([]int{}) })
+		})
+	}
+
+	t.Run("error-returning variants return ErrNilProcess", func(t *testing.T) {
+		outputs, errs := ExecuteWithError[int, int](4, inputs, nil)
+		assert.Empty(t, outputs)
+		assert.Equal(t, []error{ErrNilProcess}, errs)
+
+		_, err := ExecuteUntilError[int, int](4, inputs, nil)
+		assert.ErrorIs(t, err, ErrNilProcess)
+
+		outputs, errs = ExecuteWithError[int, int](4, []int{}, nil)
+		assert.Empty(t, outputs)
+		assert.Empty(t, errs)
+	})
+
+	t.Run("empty inputs return an empty slice", func(t *testing.T) {
+		outputs := Execute[int, int](4, []int{}, nil)
+		assert.NotNil(t, outputs)
+		assert.Empty(t, outputs)
+	})
+}
+
+func TestEarlyExitDoesNotLeak(t *testing.T) {
+	inputs := make([]int, 1000)
+	for i := range inputs {
+		inputs[i] = i
+	}
+	errFail := errors.New("fail")
+
+	testCases := []struct {
+		name    string
+		execute func()
+	}{
+		{
+			name: "ExecuteUntilError",
+			execute: func() {
+				ExecuteUntilError(8, inputs, func(in int) (int, error) {
+					if in == 10 {
+						return 0, errFail
+					}
+					return in, nil
+				})
+			},
+		},
+		{
+			name: "ExecuteN",
+			execute: func() {
+				ExecuteN(8, 5, inputs, func(in int) (int, bool) {
+					return in, true
+				})
+			},
+		},
+		{
+			name: "AnyMatch",
+			execute: func() {
+				AnyMatch(8, inputs, func(in int) bool {
+					return in == 10
+				})
+			},
+		},
+		{
+			name: "ExecuteWithCircuitBreaker",
+			execute: func() {
+				ExecuteWithCircuitBreaker(8, 1, inputs, func(in int) (int, error) {
+					return 0, errFail
+				})
+			},
+		},
+		{
+			name: "ExecuteWithOptions with an unbuffered output and a propagated panic",
+			execute: func() {
+				defer func() {
+					recover()
+				}()
+				ExecuteWithOptions(8, inputs, func(in int) (int, error) {
+					if in == 10 {
+						panic("unexpected input")
+					}
+					return in, nil
+				}, Options[int]{BufferSize: -1, PanicPolicy: PanicPropagate})
+			},
+		},
+		{
+			name: "ExecuteContext canceled mid-batch",
+			execute: func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				ExecuteContext(ctx, 8, inputs, func(_ context.Context, in int) int {
+					if in == 10 {
+						cancel()
+					}
+					return in
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			goroutinesBefore := runtime.NumGoroutine()
+			tc.execute()
+			assertNoGoroutineLeak(t, goroutinesBefore)
+		})
+	}
+}
+
+func TestSequential(t *testing.T) {
+	Sequential = true
+	defer func() {
+		Sequential = false
+	}()
+
+	inputs := make([]int, 50)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	testCases := []struct {
+		name    string
+		execute func(process func(int) int) []int
+	}{
+		{
+			name: "Execute",
+			execute: func(process func(int) int) []int {
+				return Execute(8, inputs, process)
+			},
+		},
+		{
+			name: "ExecuteWeighted",
+			execute: func(process func(int) int) []int {
+				return ExecuteWeighted(8, inputs, func(in int) int { return in }, process)
+			},
+		},
+		{
+			name: "ExecuteAdaptive",
+			execute: func(process func(int) int) []int {
+				return ExecuteAdaptive(2, 8, inputs, process)
+			},
+		},
+		{
+			name: "ExecuteHedged",
+			execute: func(process func(int) int) []int {
+				// shorter than every process call, so every input would be hedged without Sequential
+				return ExecuteHedged(8, 10*time.Microsecond, inputs, process)
+			},
+		},
+		{
+			name: "ExecuteStream",
+			execute: func(process func(int) int) []int {
+				return drain(ExecuteStream(8, generate(inputs...), process))
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var counter peakCounter
+			var mu sync.Mutex
+			processed := []int{}
+			outputs := tc.execute(func(in int) int {
+				counter.enter()
+				defer counter.exit()
+				mu.Lock()
+				processed = append(processed, in)
+				mu.Unlock()
+				time.Sleep(100 * time.Microsecond)
+				return in
+			})
+
+			assert.Equal(t, inputs, processed)
+			assert.Equal(t, inputs, outputs)
+			assert.Equal(t, int64(1), counter.max())
+		})
+	}
+}
+
+func BenchmarkExecuteFewInputsManyRoutines(b *testing.B) {
+	inputs := []int{1, 2, 3}
+	peakGoroutines := 0
+	var mu sync.Mutex
+	process := func(in int) int {
+		mu.Lock()
+		defer mu.Unlock()
+		if n := runtime.NumGoroutine(); n > peakGoroutines {
+			peakGoroutines = n
+		}
+		return in
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Execute(100, inputs, process)
+	}
+	b.ReportMetric(float64(peakGoroutines), "peak-goroutines")
+}
+
+// executeRoundRobin is the former implementation of Execute, which assigns the input at index i to the worker i modulo
+// numOfRoutines, kept as a baseline for the benchmarks.
+func executeRoundRobin[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) TypeOut) []TypeOut {
+	inputChannels := make([](chan TypeIn), numOfRoutines)
+	outputChannel := make(chan TypeOut)
+	for i := 0; i < numOfRoutines; i++ {
+		inputChannels[i] = make(chan TypeIn)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(numOfRoutines)
+	for i := 0; i < numOfRoutines; i++ {
+		go func(inputChan chan TypeIn) {
+			defer wg.Done()
+			for input := range inputChan {
+				outputChannel <- process(input)
+			}
+		}(inputChannels[i])
+	}
+
+	go func() {
+		for i, input := range inputs {
+			inputChannels[i%numOfRoutines] <- input
+		}
+		for _, inputChan := range inputChannels {
+			close(inputChan)
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(outputChannel)
+	}()
+
+	outputs := []TypeOut{}
+	for o := range outputChannel {
+		outputs = append(outputs, o)
+	}
+	return outputs
+}
+
+// skewedInputs returns inputs where every eighth one sleeps for 100ms and the rest are instant, so that all of the slow
+// inputs land on the same worker when they are assigned round-robin over four workers.
+func skewedInputs() []time.Duration {
+	inputs := make([]time.Duration, 40)
+	for i := range inputs {
+		if i%8 == 0 {
+			inputs[i] = 100 * time.Millisecond
+		}
+	}
+	return inputs
+}
+
+func sleepFor(d time.Duration) time.Duration {
+	time.Sleep(d)
+	return d
+}
+
+func BenchmarkExecuteSkewedWorkload(b *testing.B) {
+	inputs := skewedInputs()
+	for i := 0; i < b.N; i++ {
+		Execute(4, inputs, sleepFor)
+	}
+}
+
+func BenchmarkExecuteSkewedWorkloadRoundRobin(b *testing.B) {
+	inputs := skewedInputs()
+	for i := 0; i < b.N; i++ {
+		executeRoundRobin(4, inputs, sleepFor)
+	}
+}
+
+func BenchmarkExecuteLargeInputs(b *testing.B) {
+	inputs := make([]int, 1000000)
+	for i := range inputs {
+		inputs[i] = i
+	}
+	process := func(in int) int {
+		return in + 1
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Execute(8, inputs, process)
+	}
+}
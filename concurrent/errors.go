@@ -0,0 +1,77 @@
+package concurrent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// ErrItemTimeout is reported for an input whose process call did not finish within the given timeout.
+var ErrItemTimeout = errors.New("item processing timed out")
+
+// ErrCircuitOpen is reported for an input that is skipped because the circuit breaker has tripped.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// ErrContextCanceled is returned when an execution is cut short because its context is done. The returned error also
+// wraps ctx.Err(), so errors.Is matches both ErrContextCanceled and context.Canceled or context.DeadlineExceeded.
+var ErrContextCanceled = errors.New("execution canceled")
+
+// ErrPanic is matched by errors.Is against every *PanicError, i.e. against the error reported for an input whose
+// process call panicked, the *PanicError holding the recovered value.
+var ErrPanic = errors.New("process panicked")
+
+// ErrLengthMismatch is returned when slices that must be aligned element-wise have different lengths.
+var ErrLengthMismatch = errors.New("slices have different lengths")
+
+// ErrNilProcess is returned, or panicked with by the functions that do not return an error, when the function that
+// processes the inputs is nil while there are inputs to process. A nil function with no inputs is harmless.
+var ErrNilProcess = errors.New("concurrent: process function is nil")
+
+// ItemError is the error reported for a single input that failed to be processed. Index is the position of that
+// input in the inputs slice, and Err is the error returned by the process function.
+type ItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("input %d: %s", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error, so errors.Is and errors.As could be used against the ItemError.
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is the error reported for an input whose process call panicked. Value is the value passed to panic, and
+// Stack is the stack trace of the goroutine at the time it panicked.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v\n\n%s", e.Value, e.Stack)
+}
+
+// Is reports whether target is ErrPanic, so errors.Is(err, ErrPanic) matches every *PanicError.
+func (e *PanicError) Is(target error) bool {
+	return target == ErrPanic
+}
+
+// canceled returns the error of an execution cut short because ctx is done, which wraps both ErrContextCanceled and
+// ctx.Err().
+func canceled(ctx context.Context) error {
+	return fmt.Errorf("%w: %w", ErrContextCanceled, ctx.Err())
+}
+
+// protect calls process with the input, turning a panic inside process into an error returned to the caller.
+func protect[TypeIn any, TypeOut any](process func(input TypeIn) (TypeOut, error), input TypeIn) (output TypeOut, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return process(input)
+}
@@ -0,0 +1,99 @@
+package concurrent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	errFail := errors.New("fail")
+	inputs := []int{0, 1, 2}
+
+	testCases := []struct {
+		name     string
+		errs     func() []error
+		sentinel error
+	}{
+		{
+			name: "a process call that takes too long is reported as ErrItemTimeout",
+			errs: func() []error {
+				_, errs := ExecuteWithTimeout(3, 10*time.Millisecond, inputs, func(in int) (int, error) {
+					time.Sleep(50 * time.Millisecond)
+					return in, nil
+				})
+				return errs
+			},
+			sentinel: ErrItemTimeout,
+		},
+		{
+			name: "an input skipped by a tripped circuit breaker is reported as ErrCircuitOpen",
+			errs: func() []error {
+				_, errs := ExecuteWithCircuitBreaker(1, 1, inputs, func(in int) (int, error) {
+					return 0, errFail
+				})
+				return errs[1:]
+			},
+			sentinel: ErrCircuitOpen,
+		},
+		{
+			name: "an execution cut short by its context is reported as ErrContextCanceled",
+			errs: func() []error {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_, err := ExecuteContext(ctx, 3, inputs, func(_ context.Context, in int) int {
+					return in
+				})
+				return []error{err}
+			},
+			sentinel: ErrContextCanceled,
+		},
+		{
+			name: "a panicking process call is reported as ErrPanic",
+			errs: func() []error {
+				_, errs := ExecuteWithError(3, inputs, func(in int) (int, error) {
+					panic("unexpected input")
+				})
+				return errs
+			},
+			sentinel: ErrPanic,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := tc.errs()
+			assert.NotEmpty(t, errs)
+			for _, err := range errs {
+				assert.ErrorIs(t, err, tc.sentinel)
+			}
+		})
+	}
+
+	t.Run("ErrContextCanceled also wraps the error of the context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+		defer cancel()
+		<-ctx.Done()
+
+		_, errs := ExecuteContextState(ctx, 3, inputs, func(context.Context) (int, error) {
+			return 0, nil
+		}, func(_ context.Context, _ int, in int) (int, error) {
+			return in, nil
+		}, nil)
+		if assert.Len(t, errs, 1) {
+			assert.ErrorIs(t, errs[0], ErrContextCanceled)
+			assert.ErrorIs(t, errs[0], context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("a plain error matches none of the sentinels", func(t *testing.T) {
+		err := &ItemError{Index: 0, Err: errFail}
+		for _, sentinel := range []error{ErrItemTimeout, ErrCircuitOpen, ErrContextCanceled, ErrPanic} {
+			assert.NotErrorIs(t, err, sentinel)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package concurrent_test
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+)
+
+func ExampleThen() {
+	names := NewPipeline([]string{
+		"alice",
+		"bob",
+		"john",
+	})
+	uppercased := Then(names, 2, strings.ToUpper)
+	greetings := Then(uppercased, 2, func(name string) string {
+		return fmt.Sprintf("Hi %s", name)
+	})
+
+	outputs := greetings.Collect()
+	sort.Strings(outputs)
+	for _, output := range outputs {
+		fmt.Println(output)
+	}
+
+	// Output:
+	// Hi ALICE
+	// Hi BOB
+	// Hi JOHN
+}
@@ -0,0 +1,114 @@
+package concurrent
+
+import (
+	"sync"
+	"time"
+)
+
+// adaptiveIdleTimeout is how long a worker of ExecuteAdaptive waits for an input before retiring.
+const adaptiveIdleTimeout = 10 * time.Millisecond
+
+// ExecuteAdaptive works like Execute, but the number of workers follows the pending work instead of being fixed. It
+// starts with minRoutines workers, and whenever an input is ready but no worker is free to take it, a new worker is
+// spawned, up to maxRoutines workers. A worker that stays idle for a while retires, down to minRoutines workers. A
+// minRoutines that is zero or negative is treated as one, and a maxRoutines below minRoutines as minRoutines.
+func ExecuteAdaptive[TypeIn any, TypeOut any](minRoutines, maxRoutines int, inputs []TypeIn, process func(input TypeIn) TypeOut) []TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	if minRoutines <= 0 || Sequential {
+		minRoutines = 1
+	}
+	minRoutines, maxRoutines = clampRoutines(minRoutines), clampRoutines(maxRoutines)
+	if maxRoutines < minRoutines || Sequential {
+		maxRoutines = minRoutines
+	}
+	if len(inputs) == 0 {
+		return []TypeOut{}
+	}
+
+	inputChannel := make(chan TypeIn)
+	outputChannel := make(chan TypeOut, maxRoutines)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	active := 0
+
+	// retire reports whether the calling idle worker could exit, in which case it is no longer counted as active
+	retire := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if active <= minRoutines {
+			return false
+		}
+		active--
+		return true
+	}
+
+	worker := func() {
+		defer wg.Done()
+		idle := time.NewTimer(adaptiveIdleTimeout)
+		defer idle.Stop()
+		for {
+			select {
+			case input, ok := <-inputChannel:
+				if !ok {
+					return
+				}
+				outputChannel <- process(input)
+			case <-idle.C:
+				if retire() {
+					return
+				}
+			}
+			if !idle.Stop() {
+				select {
+				case <-idle.C:
+				default:
+				}
+			}
+			idle.Reset(adaptiveIdleTimeout)
+		}
+	}
+
+	// spawn reports whether a new worker is spawned, which is not the case when there are already maxRoutines
+	spawn := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if active >= maxRoutines {
+			return false
+		}
+		active++
+		wg.Add(1)
+		go worker()
+		return true
+	}
+
+	for i := 0; i < minRoutines && i < len(inputs); i++ {
+		spawn()
+	}
+
+	// distribute inputs, spawning a worker whenever none is free to take the next input
+	go func() {
+		for _, input := range inputs {
+			select {
+			case inputChannel <- input:
+				continue
+			default:
+			}
+			spawn()
+			inputChannel <- input
+		}
+		close(inputChannel)
+		wg.Wait()
+		close(outputChannel)
+	}()
+
+	outputs := make([]TypeOut, 0, len(inputs))
+	for o := range outputChannel {
+		outputs = append(outputs, o)
+	}
+
+	return outputs
+}
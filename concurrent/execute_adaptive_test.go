@@ -0,0 +1,99 @@
+package concurrent_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteAdaptive(t *testing.T) {
+	inputs := make([]int, 200)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	testCases := []struct {
+		name         string
+		minRoutines  int
+		maxRoutines  int
+		expectedPeak int64
+	}{
+		{
+			name:         "scales up to max routines",
+			minRoutines:  1,
+			maxRoutines:  8,
+			expectedPeak: 8,
+		},
+		{
+			name:         "max routines below min routines",
+			minRoutines:  3,
+			maxRoutines:  1,
+			expectedPeak: 3,
+		},
+		{
+			name:         "non-positive min routines",
+			minRoutines:  0,
+			maxRoutines:  0,
+			expectedPeak: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var counter peakCounter
+			outputs := ExecuteAdaptive(tc.minRoutines, tc.maxRoutines, inputs, func(in int) int {
+				counter.enter()
+				defer counter.exit()
+				time.Sleep(time.Millisecond)
+				return in
+			})
+
+			assert.ElementsMatch(t, inputs, outputs)
+			assert.LessOrEqual(t, counter.max(), tc.expectedPeak)
+			assert.Greater(t, counter.max(), int64(0))
+		})
+	}
+
+	t.Run("empty inputs", func(t *testing.T) {
+		assert.Empty(t, ExecuteAdaptive(1, 4, []int{}, func(in int) int {
+			return in
+		}))
+	})
+}
+
+// burstyInputs returns bursts of slow inputs separated by long stretches of instant ones.
+func burstyInputs() []time.Duration {
+	inputs := []time.Duration{}
+	for burst := 0; burst < 5; burst++ {
+		for i := 0; i < 40; i++ {
+			inputs = append(inputs, 2*time.Millisecond)
+		}
+		for i := 0; i < 1000; i++ {
+			inputs = append(inputs, 0)
+		}
+	}
+	return inputs
+}
+
+func BenchmarkExecuteAdaptiveBursty(b *testing.B) {
+	inputs := burstyInputs()
+	for i := 0; i < b.N; i++ {
+		ExecuteAdaptive(2, 16, inputs, sleepFor)
+	}
+}
+
+func BenchmarkExecuteFixedMinBursty(b *testing.B) {
+	inputs := burstyInputs()
+	for i := 0; i < b.N; i++ {
+		Execute(2, inputs, sleepFor)
+	}
+}
+
+func BenchmarkExecuteFixedMaxBursty(b *testing.B) {
+	inputs := burstyInputs()
+	for i := 0; i < b.N; i++ {
+		Execute(16, inputs, sleepFor)
+	}
+}
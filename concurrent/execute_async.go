@@ -0,0 +1,22 @@
+package concurrent
+
+import "context"
+
+// ExecuteAsync works like Execute, but it returns immediately with a channel that receives every output as soon as it
+// is processed, and that is closed once all inputs are processed, or once ctx is done. When ctx is done, no new inputs
+// are handed to the workers, the outputs that have not been received yet are dropped, and the channel is closed as soon
+// as the process calls still running return.
+//
+// The workers block on sending their outputs until they are received, so a caller must either keep receiving from the
+// channel until it is closed, or cancel ctx when it stops receiving early. Any other way of abandoning the channel
+// leaks the workers.
+func ExecuteAsync[TypeIn any, TypeOut any](ctx context.Context, numOfRoutines int, inputs []TypeIn, process func(input TypeIn) TypeOut) <-chan TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	config := fanOutConfig{dropOnDone: true}
+	return fanOutWith(ctx, numOfRoutines, inputs, config, func(_, _ int, input TypeIn) TypeOut {
+		return process(input)
+	})
+}
@@ -0,0 +1,57 @@
+package concurrent_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteAsync(t *testing.T) {
+	inputs := []testInput{
+		{value: "value1"},
+		{value: "value2"},
+		{value: valueForErrorCase},
+		{value: "value3"},
+	}
+	expectedOutputs := []testOutput{}
+	for _, i := range inputs {
+		expectedOutputs = append(expectedOutputs, testProcess(i))
+	}
+
+	outputs := []testOutput{}
+	for o := range ExecuteAsync(context.Background(), 2, inputs, testProcess) {
+		outputs = append(outputs, o)
+	}
+	assert.ElementsMatch(t, expectedOutputs, outputs)
+}
+
+func TestExecuteAsyncCanceled(t *testing.T) {
+	inputs := make([]int, 1000)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	goroutinesBefore := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	outputChannel := ExecuteAsync(ctx, 4, inputs, func(in int) int {
+		time.Sleep(time.Millisecond)
+		return in
+	})
+
+	// receive a few outputs, then abandon the channel
+	for i := 0; i < 10; i++ {
+		<-outputChannel
+	}
+	cancel()
+
+	assertNoGoroutineLeak(t, goroutinesBefore)
+	received := 10
+	for range outputChannel {
+		received++
+	}
+	assert.Less(t, received, len(inputs))
+}
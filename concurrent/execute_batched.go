@@ -0,0 +1,12 @@
+package concurrent
+
+// ExecuteBatched splits the inputs into consecutive batches of batchSize inputs, the last one holding whatever inputs
+// remain, and processes the batches concurrently, so process could handle many inputs at once (e.g. a bulk insert).
+// The outputs of all batches are flattened into a single output slice which, like Execute, is not guaranteed to follow
+// the input order. A batchSize that is zero or negative means every input is a batch of its own.
+func ExecuteBatched[TypeIn any, TypeOut any](numOfRoutines, batchSize int, inputs []TypeIn, process func(batch []TypeIn) []TypeOut) []TypeOut {
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	return ExecuteFlatMap(numOfRoutines, Chunk(inputs, batchSize), process)
+}
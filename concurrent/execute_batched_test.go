@@ -0,0 +1,71 @@
+package concurrent_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteBatched(t *testing.T) {
+	inputs := make([]int, 10)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	testCases := []struct {
+		name          string
+		batchSize     int
+		expectedCalls int64
+	}{
+		{
+			name:          "batch size divides the inputs",
+			batchSize:     5,
+			expectedCalls: 2,
+		},
+		{
+			name:          "last batch is partial",
+			batchSize:     3,
+			expectedCalls: 4,
+		},
+		{
+			name:          "batch size larger than the inputs",
+			batchSize:     20,
+			expectedCalls: 1,
+		},
+		{
+			name:          "zero batch size means one batch per input",
+			batchSize:     0,
+			expectedCalls: 10,
+		},
+		{
+			name:          "negative batch size means one batch per input",
+			batchSize:     -1,
+			expectedCalls: 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int64
+			outputs := ExecuteBatched(3, tc.batchSize, inputs, func(batch []int) []int {
+				atomic.AddInt64(&calls, 1)
+				expectedSize := tc.batchSize
+				if expectedSize <= 0 {
+					expectedSize = 1
+				}
+				assert.LessOrEqual(t, len(batch), expectedSize)
+
+				doubled := make([]int, len(batch))
+				for i, in := range batch {
+					doubled[i] = in * 2
+				}
+				return doubled
+			})
+
+			assert.Equal(t, tc.expectedCalls, atomic.LoadInt64(&calls))
+			assert.ElementsMatch(t, []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}, outputs)
+		})
+	}
+}
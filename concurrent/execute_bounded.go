@@ -0,0 +1,60 @@
+package concurrent
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Limiter is a budget of workers shared by the ExecuteBounded calls it is passed to, so that nested calls, such as
+// a process function that calls ExecuteBounded again to fan out over the children of a tree node, do not multiply the
+// number of workers at every level. A Limiter must be created with NewLimiter.
+type Limiter struct {
+	slots *Semaphore
+}
+
+// NewLimiter returns a Limiter that lets the ExecuteBounded calls sharing it run at most maxRoutines extra workers at
+// any time, on top of the goroutine of the outermost call, so at most maxRoutines + 1 goroutines are running process
+// calls at once. The slot of an extra worker is freed once it is done processing, right before its goroutine exits, so
+// the number of live goroutines could briefly exceed that bound. Like numOfRoutines, a maxRoutines that is zero or
+// negative defaults to DefaultRoutines, and one that is above MaxRoutines is clamped to it.
+func NewLimiter(maxRoutines int) *Limiter {
+	return &Limiter{slots: NewSemaphore(clampRoutines(positiveOrDefault(maxRoutines)))}
+}
+
+// ExecuteBounded works like Execute, but the workers are drawn from the budget of limiter, and the output slice keeps
+// the order of the inputs like ExecuteOrdered. The calling goroutine always takes part in the processing, and up to
+// numOfRoutines - 1 more workers are spawned, but only as long as limiter has room for them when ExecuteBounded is
+// called. So a nested call never waits for the budget, which could deadlock when the outer calls hold all of it, and
+// falls back to processing its inputs on the calling goroutine instead.
+func ExecuteBounded[TypeIn any, TypeOut any](limiter *Limiter, numOfRoutines int, inputs []TypeIn, process func(input TypeIn) TypeOut) []TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	outputs := make([]TypeOut, len(inputs))
+	var next int64
+	work := func() {
+		for {
+			index := int(atomic.AddInt64(&next, 1) - 1)
+			if index >= len(inputs) {
+				return
+			}
+			outputs[index] = process(inputs[index])
+		}
+	}
+
+	var wg sync.WaitGroup
+	for extra := workerCount(numOfRoutines, len(inputs)) - 1; extra > 0 && limiter.slots.TryAcquire(); extra-- {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer limiter.slots.Release()
+			work()
+		}()
+	}
+
+	work()
+	wg.Wait()
+
+	return outputs
+}
@@ -0,0 +1,63 @@
+package concurrent_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteBounded(t *testing.T) {
+	t.Run("outputs keep the order of the inputs", func(t *testing.T) {
+		inputs := make([]int, 100)
+		for i := range inputs {
+			inputs[i] = i
+		}
+		outputs := ExecuteBounded(NewLimiter(4), 4, inputs, func(in int) int {
+			return in * 2
+		})
+		for i, o := range outputs {
+			assert.Equal(t, i*2, o)
+		}
+	})
+
+	t.Run("nested calls share the budget of the limiter", func(t *testing.T) {
+		const maxRoutines = 5
+		limiter := NewLimiter(maxRoutines)
+
+		var counter peakCounter
+		var mu sync.Mutex
+		leaves := 0
+		leaf := func(in int) int {
+			counter.enter()
+			defer counter.exit()
+			mu.Lock()
+			leaves++
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			return in
+		}
+		// a tree two levels deep, where every node fans out over 8 children with 8 workers
+		children := []int{0, 1, 2, 3, 4, 5, 6, 7}
+		ExecuteBounded(limiter, 8, children, func(int) int {
+			ExecuteBounded(limiter, 8, children, func(int) int {
+				ExecuteBounded(limiter, 8, children, leaf)
+				return 0
+			})
+			return 0
+		})
+
+		assert.Equal(t, 8*8*8, leaves)
+		// the extra workers of the limiter, plus the calling goroutine which always takes part
+		assert.LessOrEqual(t, counter.max(), int64(maxRoutines+1))
+	})
+
+	t.Run("empty inputs", func(t *testing.T) {
+		outputs := ExecuteBounded(NewLimiter(2), 2, []int{}, func(in int) int {
+			return in
+		})
+		assert.Empty(t, outputs)
+	})
+}
@@ -0,0 +1,60 @@
+package concurrent
+
+import "context"
+
+// ExecuteByKey works like Execute, but all inputs sharing the same key, as returned by keyFunc, are processed by the
+// same worker, one after the other in the order of the inputs slice, so the worker could keep whatever it caches per
+// key without any locking, and the inputs of a key never run concurrently. The keys are spread over the workers up
+// front, the keys with the most inputs first, every key going to the worker with the fewest inputs so far. This trades
+// some load balance for the locality, since the inputs of a key could not be shared by the workers even when some of
+// them are idle.
+func ExecuteByKey[TypeIn any, TypeOut any, K comparable](numOfRoutines int, inputs []TypeIn, keyFunc func(input TypeIn) K, process func(input TypeIn) TypeOut) []TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	config := fanOutConfig{
+		assign: func(numOfWorkers int) [][]int {
+			return assignByKey(numOfWorkers, inputs, keyFunc)
+		},
+	}
+	outputChannel := fanOutWith(context.Background(), numOfRoutines, inputs, config, func(_, _ int, input TypeIn) TypeOut {
+		return process(input)
+	})
+
+	outputs := make([]TypeOut, 0, len(inputs))
+	for o := range outputChannel {
+		outputs = append(outputs, o)
+	}
+
+	return outputs
+}
+
+// assignByKey assigns the indexes of the inputs to numOfWorkers workers so that the inputs of the same key go to the
+// same worker, see ExecuteByKey.
+func assignByKey[TypeIn any, K comparable](numOfWorkers int, inputs []TypeIn, keyFunc func(input TypeIn) K) [][]int {
+	groupOf := map[K]int{}
+	groups := [][]int{}
+	for i, input := range inputs {
+		key := keyFunc(input)
+		group, ok := groupOf[key]
+		if !ok {
+			group = len(groups)
+			groupOf[key] = group
+			groups = append(groups, nil)
+		}
+		groups[group] = append(groups[group], i)
+	}
+
+	// the groups are spread like weighted inputs, weighing as many inputs as they hold
+	assigned := make([][]int, numOfWorkers)
+	for worker, assignedGroups := range assignByWeight(numOfWorkers, groups, func(group []int) int {
+		return len(group)
+	}) {
+		for _, group := range assignedGroups {
+			assigned[worker] = append(assigned[worker], groups[group]...)
+		}
+	}
+
+	return assigned
+}
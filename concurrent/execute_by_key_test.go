@@ -0,0 +1,65 @@
+package concurrent_test
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+// goroutineID returns the id of the calling goroutine, parsed from the header of its stack trace.
+func goroutineID() int {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	id, err := strconv.Atoi(string(buf[:bytes.IndexByte(buf, ' ')]))
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+func TestExecuteByKey(t *testing.T) {
+	type keyedInput struct {
+		key   string
+		value int
+	}
+
+	inputs := []keyedInput{}
+	for i := 0; i < 100; i++ {
+		inputs = append(inputs, keyedInput{key: strconv.Itoa(i % 7), value: i})
+	}
+
+	var mu sync.Mutex
+	workersOfKey := map[string]map[int]bool{}
+	valuesOfKey := map[string][]int{}
+	outputs := ExecuteByKey(4, inputs, func(in keyedInput) string {
+		return in.key
+	}, func(in keyedInput) int {
+		mu.Lock()
+		defer mu.Unlock()
+		if workersOfKey[in.key] == nil {
+			workersOfKey[in.key] = map[int]bool{}
+		}
+		workersOfKey[in.key][goroutineID()] = true
+		valuesOfKey[in.key] = append(valuesOfKey[in.key], in.value)
+		return in.value
+	})
+
+	assert.Len(t, outputs, len(inputs))
+	assert.Len(t, workersOfKey, 7)
+	workers := map[int]bool{}
+	for key, ids := range workersOfKey {
+		assert.Len(t, ids, 1, "key %s", key)
+		for id := range ids {
+			workers[id] = true
+		}
+		// the inputs of a key are processed in the order of the inputs slice
+		assert.IsIncreasing(t, valuesOfKey[key])
+	}
+	assert.Len(t, workers, 4)
+}
@@ -0,0 +1,55 @@
+package concurrent
+
+import "context"
+
+// ExecuteContext works like Execute, but the execution could be aborted through ctx, which is also passed to every
+// process call. Once ctx is done, no new inputs are handed to the workers, and ExecuteContext returns as soon as the
+// in-flight process calls return. Every goroutine spawned by ExecuteContext has exited by the time it returns, so
+// process should respect ctx to keep the cancellation prompt.
+//
+// The partial outputs returned on cancellation are exactly the outputs of the process calls that returned before ctx
+// was done. The output of a process call that was still running when ctx was done is discarded, even if it returns
+// normally afterwards, since it may have been cut short by the cancellation. So when ctx is done before the execution
+// starts, nothing is processed and no output is returned, and when ctx is only done after every process call has
+// returned, every output is returned. The returned error is nil when every input has its output returned, and wraps
+// both ErrContextCanceled and ctx.Err() otherwise.
+func ExecuteContext[TypeIn any, TypeOut any](ctx context.Context, numOfRoutines int, inputs []TypeIn, process func(ctx context.Context, input TypeIn) TypeOut) ([]TypeOut, error) {
+	outputs, _, err := ExecuteContextWithStats(ctx, numOfRoutines, inputs, process)
+	return outputs, err
+}
+
+// ExecuteContextWithStats works like ExecuteContext, but it also returns the Stats of the execution, in which the
+// failed inputs are the ones whose output was discarded because ctx was done before their process call returned.
+func ExecuteContextWithStats[TypeIn any, TypeOut any](ctx context.Context, numOfRoutines int, inputs []TypeIn, process func(ctx context.Context, input TypeIn) TypeOut) ([]TypeOut, Stats, error) {
+	if process == nil && len(inputs) > 0 {
+		return nil, Stats{}, ErrNilProcess
+	}
+
+	type result struct {
+		output    TypeOut
+		completed bool
+	}
+
+	counter := newStatsCounter(len(inputs))
+	outputChannel := fanOut(ctx, numOfRoutines, inputs, func(_, index int, input TypeIn) result {
+		counter.process(index)
+		output := process(ctx, input)
+		completed := ctx.Err() == nil
+		if !completed {
+			counter.fail()
+		}
+		return result{output: output, completed: completed}
+	})
+
+	outputs := make([]TypeOut, 0, len(inputs))
+	for r := range outputChannel {
+		if r.completed {
+			outputs = append(outputs, r.output)
+		}
+	}
+
+	if len(outputs) == len(inputs) {
+		return outputs, counter.stats(), nil
+	}
+	return outputs, counter.stats(), canceled(ctx)
+}
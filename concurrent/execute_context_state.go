@@ -0,0 +1,72 @@
+package concurrent
+
+import "context"
+
+// ExecuteContextState combines ExecuteContext and ExecuteWithState, for workers that need both a state of their own
+// and the cancellation of ctx. Every worker creates its state by calling newState with ctx once before processing its
+// first input, and passes it to closeState right before exiting, unless closeState is nil. A worker whose newState
+// fails does not process any input, and its error is returned among the errors as is, while the other workers go on
+// with the inputs, so when newState fails for every worker, no input is processed at all.
+//
+// The errors returned by process, and the panics that are recovered into a *PanicError, are returned as *ItemError.
+// Once ctx is done, no new inputs are handed to the workers, and an error wrapping both ErrContextCanceled and
+// ctx.Err() is returned among the errors when some inputs were left unprocessed because of it. The state of every
+// worker is still passed to closeState in that case.
+func ExecuteContextState[TypeIn any, TypeOut any, S any](ctx context.Context, numOfRoutines int, inputs []TypeIn, newState func(ctx context.Context) (S, error), process func(ctx context.Context, state S, input TypeIn) (TypeOut, error), closeState func(state S)) ([]TypeOut, []error) {
+	if process == nil && len(inputs) > 0 {
+		return nil, []error{ErrNilProcess}
+	}
+
+	type result struct {
+		output TypeOut
+		err    error
+	}
+
+	numOfWorkers := workerCount(numOfRoutines, len(inputs))
+	states := make([]S, numOfWorkers)
+	stateErrs := make([]error, numOfWorkers)
+	config := fanOutConfig{
+		onWorkerStart: func(worker int) bool {
+			states[worker], stateErrs[worker] = newState(ctx)
+			return stateErrs[worker] == nil
+		},
+	}
+	if closeState != nil {
+		config.onWorkerExit = func(worker int) {
+			closeState(states[worker])
+		}
+	}
+
+	outputChannel := fanOutWith(ctx, numOfRoutines, inputs, config, func(worker, index int, input TypeIn) result {
+		output, err := protect(func(input TypeIn) (TypeOut, error) {
+			return process(ctx, states[worker], input)
+		}, input)
+		if err != nil {
+			return result{err: &ItemError{Index: index, Err: err}}
+		}
+		return result{output: output}
+	})
+
+	outputs := make([]TypeOut, 0, len(inputs))
+	errs := []error{}
+	processed := 0
+	for r := range outputChannel {
+		processed++
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		outputs = append(outputs, r.output)
+	}
+
+	for _, err := range stateErrs {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if processed < len(inputs) && ctx.Err() != nil {
+		errs = append(errs, canceled(ctx))
+	}
+
+	return outputs, errs
+}
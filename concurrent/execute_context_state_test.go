@@ -0,0 +1,138 @@
+package concurrent_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteContextState(t *testing.T) {
+	inputs := make([]int, 100)
+	for i := range inputs {
+		inputs[i] = i
+	}
+	errState := errors.New("cannot create state")
+
+	testCases := []struct {
+		name            string
+		failingStates   int64
+		expectedOutputs int
+		expectedErrs    int
+	}{
+		{
+			name:            "every worker has its state",
+			failingStates:   0,
+			expectedOutputs: 100,
+			expectedErrs:    0,
+		},
+		{
+			name:            "the workers without a state do not run",
+			failingStates:   2,
+			expectedOutputs: 100,
+			expectedErrs:    2,
+		},
+		{
+			name:            "no input is processed when every state fails",
+			failingStates:   4,
+			expectedOutputs: 0,
+			expectedErrs:    4,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			states := []*testWorkerState{}
+			var created int64
+			newState := func(_ context.Context) (*testWorkerState, error) {
+				if atomic.AddInt64(&created, 1) <= tc.failingStates {
+					return nil, errState
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				state := &testWorkerState{id: len(states)}
+				states = append(states, state)
+				return state, nil
+			}
+			closeState := func(state *testWorkerState) {
+				state.closed = true
+			}
+
+			outputs, errs := ExecuteContextState(context.Background(), 4, inputs, newState, func(_ context.Context, state *testWorkerState, in int) (int, error) {
+				state.items++
+				return in, nil
+			}, closeState)
+
+			assert.Len(t, outputs, tc.expectedOutputs)
+			assert.Len(t, errs, tc.expectedErrs)
+			for _, err := range errs {
+				assert.ErrorIs(t, err, errState)
+			}
+			items := 0
+			for _, state := range states {
+				items += state.items
+				assert.True(t, state.closed)
+			}
+			assert.Equal(t, tc.expectedOutputs, items)
+		})
+	}
+
+	t.Run("the states are closed on cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var mu sync.Mutex
+		states := []*testWorkerState{}
+		newState := func(_ context.Context) (*testWorkerState, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			state := &testWorkerState{id: len(states)}
+			states = append(states, state)
+			return state, nil
+		}
+
+		var calls int64
+		outputs, errs := ExecuteContextState(ctx, 4, inputs, newState, func(ctx context.Context, _ *testWorkerState, in int) (int, error) {
+			if atomic.AddInt64(&calls, 1) == 10 {
+				cancel()
+			}
+			return in, ctx.Err()
+		}, func(state *testWorkerState) {
+			state.closed = true
+		})
+
+		assert.Less(t, len(outputs), len(inputs))
+		assert.NotEmpty(t, errs)
+		assert.ErrorIs(t, errs[len(errs)-1], context.Canceled)
+		assert.Len(t, states, 4)
+		for _, state := range states {
+			assert.True(t, state.closed)
+		}
+	})
+
+	t.Run("errors and panics of process are reported with their index", func(t *testing.T) {
+		newState := func(_ context.Context) (int, error) {
+			return 0, nil
+		}
+		outputs, errs := ExecuteContextState(context.Background(), 2, []int{0, 1, 2}, newState, func(_ context.Context, _ int, in int) (int, error) {
+			if in == 1 {
+				panic("unexpected input")
+			}
+			return in, nil
+		}, nil)
+
+		assert.ElementsMatch(t, []int{0, 2}, outputs)
+		if assert.Len(t, errs, 1) {
+			var itemErr *ItemError
+			var panicErr *PanicError
+			assert.True(t, errors.As(errs[0], &itemErr))
+			assert.Equal(t, 1, itemErr.Index)
+			assert.True(t, errors.As(errs[0], &panicErr))
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package concurrent_test
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+// assertNoGoroutineLeak asserts that the number of goroutines settles back to at most goroutinesBefore, giving the
+// goroutines that are about to exit a moment to do so.
+func assertNoGoroutineLeak(t *testing.T, goroutinesBefore int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > goroutinesBefore && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), goroutinesBefore)
+}
+
+func TestExecuteContext(t *testing.T) {
+	inputs := make([]int, 100)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	t.Run("all inputs are processed when ctx is not canceled", func(t *testing.T) {
+		outputs, err := ExecuteContext(context.Background(), 4, inputs, func(_ context.Context, in int) int {
+			return in * 2
+		})
+		assert.NoError(t, err)
+		assert.Len(t, outputs, len(inputs))
+	})
+
+	t.Run("partial outputs and ctx error are returned on cancellation", func(t *testing.T) {
+		goroutinesBefore := runtime.NumGoroutine()
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		outputs, err := ExecuteContext(ctx, 4, inputs, func(ctx context.Context, in int) int {
+			select {
+			case <-time.After(10 * time.Millisecond):
+			case <-ctx.Done():
+			}
+			return in
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NotEmpty(t, outputs)
+		assert.Less(t, len(outputs), len(inputs))
+		assertNoGoroutineLeak(t, goroutinesBefore)
+	})
+}
+
+func TestExecuteContextPartialResults(t *testing.T) {
+	inputs := make([]int, 50)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	t.Run("canceled before start processes nothing", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var calls int64
+		outputs, err := ExecuteContext(ctx, 4, inputs, func(_ context.Context, in int) int {
+			atomic.AddInt64(&calls, 1)
+			return in
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, outputs)
+		assert.Zero(t, atomic.LoadInt64(&calls))
+	})
+
+	t.Run("canceled mid-batch only returns the outputs completed before", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var mu sync.Mutex
+		completedBeforeCancel := map[int]bool{}
+		var calls int64
+		outputs, err := ExecuteContext(ctx, 4, inputs, func(ctx context.Context, in int) int {
+			if atomic.AddInt64(&calls, 1) == 10 {
+				cancel()
+			}
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			completedBeforeCancel[in] = ctx.Err() == nil
+			mu.Unlock()
+			return in
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, len(outputs), len(inputs))
+		for _, o := range outputs {
+			assert.True(t, completedBeforeCancel[o])
+		}
+		assert.LessOrEqual(t, len(outputs), int(atomic.LoadInt64(&calls)))
+	})
+
+	t.Run("canceled after completion returns everything", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		outputs, err := ExecuteContext(ctx, 4, inputs, func(_ context.Context, in int) int {
+			return in
+		})
+		cancel()
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, inputs, outputs)
+	})
+}
@@ -0,0 +1,6 @@
+package concurrent
+
+// ExecuteDefault works like Execute, with DefaultRoutines goroutines.
+func ExecuteDefault[TypeIn any, TypeOut any](inputs []TypeIn, process func(input TypeIn) TypeOut) []TypeOut {
+	return Execute(DefaultRoutines, inputs, process)
+}
@@ -0,0 +1,61 @@
+package concurrent_test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteDefault(t *testing.T) {
+	inputs := make([]int, 20)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	t.Run("all inputs are processed", func(t *testing.T) {
+		outputs := ExecuteDefault(inputs, func(in int) int {
+			return in * 2
+		})
+		assert.Len(t, outputs, len(inputs))
+	})
+
+	t.Run("an overridden DefaultRoutines bounds the concurrency", func(t *testing.T) {
+		defaultRoutines := DefaultRoutines
+		defer func() {
+			DefaultRoutines = defaultRoutines
+		}()
+
+		for _, routines := range []int{1, 3} {
+			DefaultRoutines = routines
+			var counter peakCounter
+			ExecuteDefault(inputs, func(in int) int {
+				counter.enter()
+				defer counter.exit()
+				time.Sleep(time.Millisecond)
+				return in
+			})
+			assert.Equal(t, int64(routines), counter.max())
+		}
+	})
+
+	t.Run("a non-positive DefaultRoutines falls back to runtime.NumCPU", func(t *testing.T) {
+		defaultRoutines := DefaultRoutines
+		defer func() {
+			DefaultRoutines = defaultRoutines
+		}()
+
+		DefaultRoutines = 0
+		var counter peakCounter
+		outputs := Execute(0, inputs, func(in int) int {
+			counter.enter()
+			defer counter.exit()
+			time.Sleep(time.Millisecond)
+			return in
+		})
+		assert.Len(t, outputs, len(inputs))
+		assert.LessOrEqual(t, counter.max(), int64(runtime.NumCPU()))
+	})
+}
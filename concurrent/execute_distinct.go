@@ -0,0 +1,37 @@
+package concurrent
+
+import "context"
+
+// ExecuteDistinct processes every distinct input exactly once, concurrently using numOfRoutines goroutines, and
+// returns a map from every distinct input to its output. The duplicates are removed before the inputs are handed to
+// the workers, so they are never processed more than once.
+func ExecuteDistinct[TypeIn comparable, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) TypeOut) map[TypeIn]TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	type entry struct {
+		input  TypeIn
+		output TypeOut
+	}
+
+	seen := make(map[TypeIn]struct{}, len(inputs))
+	distinct := make([]TypeIn, 0, len(inputs))
+	for _, input := range inputs {
+		if _, ok := seen[input]; !ok {
+			seen[input] = struct{}{}
+			distinct = append(distinct, input)
+		}
+	}
+
+	outputChannel := fanOut(context.Background(), numOfRoutines, distinct, func(_, _ int, input TypeIn) entry {
+		return entry{input: input, output: process(input)}
+	})
+
+	outputs := make(map[TypeIn]TypeOut, len(distinct))
+	for e := range outputChannel {
+		outputs[e.input] = e.output
+	}
+
+	return outputs
+}
@@ -0,0 +1,30 @@
+package concurrent_test
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteDistinct(t *testing.T) {
+	const numOfDistinct = 50
+	inputs := []int{}
+	for i := 0; i < numOfDistinct; i++ {
+		inputs = append(inputs, i, i)
+	}
+
+	var calls int64
+	outputs := ExecuteDistinct(4, inputs, func(in int) string {
+		atomic.AddInt64(&calls, 1)
+		return strconv.Itoa(in)
+	})
+
+	assert.Equal(t, int64(numOfDistinct), atomic.LoadInt64(&calls))
+	assert.Len(t, outputs, numOfDistinct)
+	for i := 0; i < numOfDistinct; i++ {
+		assert.Equal(t, strconv.Itoa(i), outputs[i])
+	}
+}
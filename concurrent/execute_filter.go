@@ -0,0 +1,45 @@
+package concurrent
+
+import "context"
+
+// ExecuteFilter evaluates the predicate for every input concurrently using numOfRoutines goroutines, and returns only
+// the inputs for which the predicate returned true. Like Execute, the returned slice is not guaranteed to follow the
+// input order, use ExecuteFilterOrdered when the order matters.
+func ExecuteFilter[TypeIn any](numOfRoutines int, inputs []TypeIn, predicate func(input TypeIn) bool) []TypeIn {
+	if predicate == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	type match struct {
+		input TypeIn
+		ok    bool
+	}
+
+	outputChannel := fanOut(context.Background(), numOfRoutines, inputs, func(_, _ int, input TypeIn) match {
+		return match{input: input, ok: predicate(input)}
+	})
+
+	outputs := []TypeIn{}
+	for m := range outputChannel {
+		if m.ok {
+			outputs = append(outputs, m.input)
+		}
+	}
+
+	return outputs
+}
+
+// ExecuteFilterOrdered works like ExecuteFilter, but the returned inputs keep the order in which they appear in the
+// inputs slice.
+func ExecuteFilterOrdered[TypeIn any](numOfRoutines int, inputs []TypeIn, predicate func(input TypeIn) bool) []TypeIn {
+	matches := ExecuteOrdered(numOfRoutines, inputs, predicate)
+
+	outputs := []TypeIn{}
+	for i, ok := range matches {
+		if ok {
+			outputs = append(outputs, inputs[i])
+		}
+	}
+
+	return outputs
+}
@@ -0,0 +1,43 @@
+package concurrent_test
+
+import (
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteFilter(t *testing.T) {
+	inputs := make([]int, 100)
+	expectedOutputs := []int{}
+	for i := range inputs {
+		inputs[i] = i
+		if i%3 == 0 {
+			expectedOutputs = append(expectedOutputs, i)
+		}
+	}
+	isMultipleOfThree := func(in int) bool {
+		return in%3 == 0
+	}
+
+	testCases := []struct {
+		name          string
+		numOfRoutines int
+	}{
+		{
+			name:          "single routine",
+			numOfRoutines: 1,
+		},
+		{
+			name:          "multiple routines",
+			numOfRoutines: 7,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ElementsMatch(t, expectedOutputs, ExecuteFilter(tc.numOfRoutines, inputs, isMultipleOfThree))
+			assert.Equal(t, expectedOutputs, ExecuteFilterOrdered(tc.numOfRoutines, inputs, isMultipleOfThree))
+		})
+	}
+}
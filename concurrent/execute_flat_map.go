@@ -0,0 +1,23 @@
+package concurrent
+
+import "context"
+
+// ExecuteFlatMap works like Execute, but process could return any number of outputs for an input, and all of them are
+// flattened into a single output slice. A process returning a nil or an empty slice contributes nothing. Like Execute,
+// the output slice is not guaranteed to follow the input order, and neither are the groups of outputs of each input.
+func ExecuteFlatMap[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) []TypeOut) []TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	outputChannel := fanOut(context.Background(), numOfRoutines, inputs, func(_, _ int, input TypeIn) []TypeOut {
+		return process(input)
+	})
+
+	outputs := []TypeOut{}
+	for o := range outputChannel {
+		outputs = append(outputs, o...)
+	}
+
+	return outputs
+}
@@ -0,0 +1,34 @@
+package concurrent_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteFlatMap(t *testing.T) {
+	documents := []string{
+		"first sentence. second sentence",
+		"",
+		"third sentence",
+		"fourth sentence. fifth sentence. sixth sentence",
+	}
+	splitSentences := func(document string) []string {
+		if document == "" {
+			return nil
+		}
+		return strings.Split(document, ". ")
+	}
+
+	outputs := ExecuteFlatMap(3, documents, splitSentences)
+	assert.ElementsMatch(t, []string{
+		"first sentence",
+		"second sentence",
+		"third sentence",
+		"fourth sentence",
+		"fifth sentence",
+		"sixth sentence",
+	}, outputs)
+}
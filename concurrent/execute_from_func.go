@@ -0,0 +1,30 @@
+package concurrent
+
+// ExecuteFromFunc works like Execute, but the inputs are pulled one at a time by calling next until it reports false,
+// instead of being read from a slice, so inputs that are produced lazily, such as the lines of a large file, never
+// have to be held in memory all at once. next is only ever called from a single goroutine, so it needs no
+// synchronization, and it is only called again once a worker is free to take the input it returns.
+func ExecuteFromFunc[TypeIn any, TypeOut any](numOfRoutines int, next func() (TypeIn, bool), process func(input TypeIn) TypeOut) []TypeOut {
+	if process == nil || next == nil {
+		panic(ErrNilProcess)
+	}
+
+	inputChannel := make(chan TypeIn)
+	go func() {
+		defer close(inputChannel)
+		for {
+			input, ok := next()
+			if !ok {
+				return
+			}
+			inputChannel <- input
+		}
+	}()
+
+	outputs := []TypeOut{}
+	for o := range ExecuteStream(numOfRoutines, inputChannel, process) {
+		outputs = append(outputs, o)
+	}
+
+	return outputs
+}
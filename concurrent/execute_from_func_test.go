@@ -0,0 +1,36 @@
+package concurrent_test
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteFromFunc(t *testing.T) {
+	t.Run("inputs are pulled from a scanner", func(t *testing.T) {
+		scanner := bufio.NewScanner(strings.NewReader("alice\nbob\njohn\n"))
+		next := func() (string, bool) {
+			if !scanner.Scan() {
+				return "", false
+			}
+			return scanner.Text(), true
+		}
+
+		outputs := ExecuteFromFunc(2, next, strings.ToUpper)
+		assert.NoError(t, scanner.Err())
+		assert.ElementsMatch(t, []string{"ALICE", "BOB", "JOHN"}, outputs)
+	})
+
+	t.Run("no input", func(t *testing.T) {
+		outputs := ExecuteFromFunc(2, func() (int, bool) {
+			return 0, false
+		}, func(in int) int {
+			return in
+		})
+		assert.NotNil(t, outputs)
+		assert.Empty(t, outputs)
+	})
+}
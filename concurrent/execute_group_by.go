@@ -0,0 +1,28 @@
+package concurrent
+
+import "context"
+
+// ExecuteGroupBy computes the key of every input concurrently by calling the keyFunc function, and groups the inputs
+// sharing the same key into the same bucket of the returned map. The map is assembled by a single goroutine, and the
+// inputs within a bucket are not guaranteed to follow the input order.
+func ExecuteGroupBy[TypeIn any, K comparable](numOfRoutines int, inputs []TypeIn, keyFunc func(input TypeIn) K) map[K][]TypeIn {
+	if keyFunc == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	type keyed struct {
+		key   K
+		input TypeIn
+	}
+
+	outputChannel := fanOut(context.Background(), numOfRoutines, inputs, func(_, _ int, input TypeIn) keyed {
+		return keyed{key: keyFunc(input), input: input}
+	})
+
+	groups := map[K][]TypeIn{}
+	for k := range outputChannel {
+		groups[k.key] = append(groups[k.key], k.input)
+	}
+
+	return groups
+}
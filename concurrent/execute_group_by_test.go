@@ -0,0 +1,27 @@
+package concurrent_test
+
+import (
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteGroupBy(t *testing.T) {
+	const numOfBuckets = 7
+	inputs := make([]int, 10000)
+	expectedGroups := map[int][]int{}
+	for i := range inputs {
+		inputs[i] = i
+		expectedGroups[i%numOfBuckets] = append(expectedGroups[i%numOfBuckets], i)
+	}
+
+	groups := ExecuteGroupBy(8, inputs, func(in int) int {
+		return in % numOfBuckets
+	})
+
+	assert.Len(t, groups, numOfBuckets)
+	for key, expected := range expectedGroups {
+		assert.ElementsMatch(t, expected, groups[key])
+	}
+}
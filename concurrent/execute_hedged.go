@@ -0,0 +1,49 @@
+package concurrent
+
+import (
+	"context"
+	"time"
+)
+
+// ExecuteHedged works like Execute, but an input whose process call is still running after hedgeAfter gets a second,
+// concurrent process call, and the output of whichever call returns first is kept, the other one being discarded.
+// This trims the latency of the occasional slow call, such as a request that hit a stalled server, at the cost of
+// calling process twice for the slow inputs, so process must be idempotent and free of side effects for hedging to be
+// safe. The call that loses the race is not interrupted: it keeps running in the background until it returns. Both
+// calls run on goroutines of their own, rather than on the worker, which only waits for them. A hedgeAfter that is zero
+// or negative disables hedging, and so does Sequential, which calls process on the worker itself.
+func ExecuteHedged[TypeIn any, TypeOut any](numOfRoutines int, hedgeAfter time.Duration, inputs []TypeIn, process func(input TypeIn) TypeOut) []TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+	if hedgeAfter <= 0 || Sequential {
+		return Execute(numOfRoutines, inputs, process)
+	}
+
+	outputChannel := fanOut(context.Background(), numOfRoutines, inputs, func(_, _ int, input TypeIn) TypeOut {
+		// buffered for both calls, so the one losing the race could still exit once process returns
+		resultChannel := make(chan TypeOut, 2)
+		call := func() {
+			resultChannel <- process(input)
+		}
+		go call()
+
+		timer := time.NewTimer(hedgeAfter)
+		defer timer.Stop()
+
+		select {
+		case output := <-resultChannel:
+			return output
+		case <-timer.C:
+			go call()
+			return <-resultChannel
+		}
+	})
+
+	outputs := make([]TypeOut, 0, len(inputs))
+	for o := range outputChannel {
+		outputs = append(outputs, o)
+	}
+
+	return outputs
+}
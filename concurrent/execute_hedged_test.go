@@ -0,0 +1,67 @@
+package concurrent_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteHedged(t *testing.T) {
+	inputs := []int{0, 1, 2, 3, 4, 5, 6, 7}
+
+	testCases := []struct {
+		name             string
+		hedgeAfter       time.Duration
+		expectedCalls    int
+		expectedDuration func(d time.Duration) bool
+	}{
+		{
+			name:          "slow inputs get a second call that wins",
+			hedgeAfter:    10 * time.Millisecond,
+			expectedCalls: len(inputs) + 2,
+			expectedDuration: func(d time.Duration) bool {
+				return d < 200*time.Millisecond
+			},
+		},
+		{
+			name:          "non-positive hedgeAfter disables hedging",
+			hedgeAfter:    0,
+			expectedCalls: len(inputs),
+			expectedDuration: func(d time.Duration) bool {
+				return d >= 200*time.Millisecond
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			calls := map[int]int{}
+			start := time.Now()
+			outputs := ExecuteHedged(4, tc.hedgeAfter, inputs, func(in int) int {
+				mu.Lock()
+				calls[in]++
+				attempt := calls[in]
+				mu.Unlock()
+				// the first call of two of the inputs stalls
+				if attempt == 1 && in%4 == 0 {
+					time.Sleep(200 * time.Millisecond)
+				}
+				return in * 2
+			})
+
+			assert.True(t, tc.expectedDuration(time.Since(start)))
+			assert.ElementsMatch(t, []int{0, 2, 4, 6, 8, 10, 12, 14}, outputs)
+			mu.Lock()
+			defer mu.Unlock()
+			total := 0
+			for _, n := range calls {
+				total += n
+			}
+			assert.Equal(t, tc.expectedCalls, total)
+		})
+	}
+}
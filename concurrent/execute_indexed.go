@@ -0,0 +1,22 @@
+package concurrent
+
+import "context"
+
+// ExecuteIndexed works like Execute, but process also receives the index of the input it processes, i.e. its position
+// in the inputs slice rather than the order in which it happens to be processed.
+func ExecuteIndexed[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(index int, input TypeIn) TypeOut) []TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	outputChannel := fanOut(context.Background(), numOfRoutines, inputs, func(_, index int, input TypeIn) TypeOut {
+		return process(index, input)
+	})
+
+	outputs := make([]TypeOut, 0, len(inputs))
+	for o := range outputChannel {
+		outputs = append(outputs, o)
+	}
+
+	return outputs
+}
@@ -0,0 +1,20 @@
+package concurrent_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteIndexed(t *testing.T) {
+	inputs := []string{"a", "b", "c", "d", "e"}
+
+	outputs := ExecuteIndexed(3, inputs, func(index int, in string) string {
+		sleepForARandomTime()
+		return fmt.Sprintf("%d:%s", index, in)
+	})
+
+	assert.ElementsMatch(t, []string{"0:a", "1:b", "2:c", "3:d", "4:e"}, outputs)
+}
@@ -0,0 +1,27 @@
+package concurrent
+
+import (
+	"errors"
+	"sort"
+)
+
+// ExecuteJoinErrors works like ExecuteWithError, but the failures are joined with errors.Join into a single error
+// that could be returned up the stack as is, ordered by the index of their input. errors.Is and errors.As see through
+// the joined error, to both the *ItemError of every failure and the error it wraps. The returned error is nil when no
+// input failed.
+func ExecuteJoinErrors[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) (TypeOut, error)) ([]TypeOut, error) {
+	outputs, errs := ExecuteWithError(numOfRoutines, inputs, process)
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errorIndex(errs[i]) < errorIndex(errs[j])
+	})
+	return outputs, errors.Join(errs...)
+}
+
+// errorIndex returns the index of the input an error was reported for, or -1 when it is not an *ItemError.
+func errorIndex(err error) int {
+	var itemErr *ItemError
+	if errors.As(err, &itemErr) {
+		return itemErr.Index
+	}
+	return -1
+}
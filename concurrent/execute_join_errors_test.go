@@ -0,0 +1,47 @@
+package concurrent_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteJoinErrors(t *testing.T) {
+	errNegative := errors.New("negative input")
+	errZero := errors.New("zero input")
+	process := func(in int) (int, error) {
+		switch {
+		case in < 0:
+			return 0, fmt.Errorf("checking %d: %w", in, errNegative)
+		case in == 0:
+			return 0, errZero
+		}
+		return in, nil
+	}
+
+	t.Run("failures are joined into a single error", func(t *testing.T) {
+		outputs, err := ExecuteJoinErrors(3, []int{1, -1, 2, 0, -3}, process)
+		assert.ElementsMatch(t, []int{1, 2}, outputs)
+		assert.ErrorIs(t, err, errNegative)
+		assert.ErrorIs(t, err, errZero)
+
+		var itemErr *ItemError
+		if assert.True(t, errors.As(err, &itemErr)) {
+			// the first of the joined errors is the one of the lowest index
+			assert.Equal(t, 1, itemErr.Index)
+		}
+		joined, ok := err.(interface{ Unwrap() []error })
+		if assert.True(t, ok) {
+			assert.Len(t, joined.Unwrap(), 3)
+		}
+	})
+
+	t.Run("no failure returns a nil error", func(t *testing.T) {
+		outputs, err := ExecuteJoinErrors(3, []int{1, 2, 3}, process)
+		assert.ElementsMatch(t, []int{1, 2, 3}, outputs)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,33 @@
+package concurrent
+
+import "context"
+
+// ExecuteMap processes every entry of the inputs map concurrently by calling the process function with its key and
+// value, and returns a new map holding the output of every entry under the same key. The returned map is assembled by
+// a single goroutine, so process does not need to synchronize anything on its own.
+func ExecuteMap[K comparable, V any, R any](numOfRoutines int, inputs map[K]V, process func(key K, value V) R) map[K]R {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	type entry struct {
+		key   K
+		value R
+	}
+
+	keys := make([]K, 0, len(inputs))
+	for k := range inputs {
+		keys = append(keys, k)
+	}
+
+	outputChannel := fanOut(context.Background(), numOfRoutines, keys, func(_, _ int, key K) entry {
+		return entry{key: key, value: process(key, inputs[key])}
+	})
+
+	outputs := make(map[K]R, len(inputs))
+	for e := range outputChannel {
+		outputs[e.key] = e.value
+	}
+
+	return outputs
+}
@@ -0,0 +1,40 @@
+package concurrent_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteMap(t *testing.T) {
+	inputs := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		inputs[fmt.Sprintf("key%d", i)] = i
+	}
+
+	var mu sync.Mutex
+	calls := map[string]int{}
+	outputs := ExecuteMap(8, inputs, func(key string, value int) string {
+		mu.Lock()
+		calls[key]++
+		mu.Unlock()
+		return fmt.Sprintf("%s=%d", key, value)
+	})
+
+	assert.Len(t, outputs, len(inputs))
+	for key, value := range inputs {
+		assert.Equal(t, fmt.Sprintf("%s=%d", key, value), outputs[key])
+		assert.Equal(t, 1, calls[key])
+	}
+}
+
+func TestExecuteMapEmpty(t *testing.T) {
+	outputs := ExecuteMap(4, map[string]int{}, func(key string, value int) int {
+		return value
+	})
+	assert.NotNil(t, outputs)
+	assert.Empty(t, outputs)
+}
@@ -0,0 +1,40 @@
+package concurrent
+
+import "sync"
+
+// ExecuteMemoized works like ExecuteOrdered, but process is called at most once for every distinct input, however
+// many times it appears in the inputs slice. The output of a repeated input is shared by all of its occurrences, and
+// when a worker reaches an input whose process call is still running on another worker, it waits for that call to
+// return instead of starting a second one. Unlike ExecuteDistinct, the output slice still has an output for every
+// input, at the same index. Since the outputs are shared, an output holding a reference, such as a pointer or a slice,
+// should not be mutated through one occurrence.
+func ExecuteMemoized[TypeIn comparable, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) TypeOut) []TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	// call is the single process call of a distinct input, whose output is set before done is closed
+	type call struct {
+		done   chan struct{}
+		output TypeOut
+	}
+
+	var mu sync.Mutex
+	calls := make(map[TypeIn]*call)
+	return ExecuteOrdered(numOfRoutines, inputs, func(input TypeIn) TypeOut {
+		mu.Lock()
+		c, ok := calls[input]
+		if ok {
+			mu.Unlock()
+			<-c.done
+			return c.output
+		}
+		c = &call{done: make(chan struct{})}
+		calls[input] = c
+		mu.Unlock()
+
+		c.output = process(input)
+		close(c.done)
+		return c.output
+	})
+}
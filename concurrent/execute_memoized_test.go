@@ -0,0 +1,35 @@
+package concurrent_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteMemoized(t *testing.T) {
+	// many duplicates, processed by several workers at the same time
+	inputs := []string{}
+	for i := 0; i < 40; i++ {
+		inputs = append(inputs, []string{"alice", "bob", "john", "bob"}[i%4])
+	}
+
+	var mu sync.Mutex
+	calls := map[string]int{}
+	outputs := ExecuteMemoized(8, inputs, func(in string) int {
+		mu.Lock()
+		calls[in]++
+		mu.Unlock()
+		// expensive enough for the other workers to request the same input meanwhile
+		time.Sleep(10 * time.Millisecond)
+		return len(in)
+	})
+
+	assert.Equal(t, map[string]int{"alice": 1, "bob": 1, "john": 1}, calls)
+	assert.Len(t, outputs, len(inputs))
+	for i, in := range inputs {
+		assert.Equal(t, len(in), outputs[i])
+	}
+}
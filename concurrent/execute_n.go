@@ -0,0 +1,52 @@
+package concurrent
+
+import "context"
+
+// ExecuteN works like Execute, but it stops once n outputs are gathered. The process function reports whether its
+// output should be kept, and as soon as n outputs are kept, no more inputs are handed to the workers, and the outputs
+// of the inputs still being processed at that moment are discarded. When fewer than n outputs are kept, every input is
+// processed and all kept outputs are returned. An n that is zero or negative processes nothing.
+func ExecuteN[TypeIn any, TypeOut any](numOfRoutines, n int, inputs []TypeIn, process func(input TypeIn) (TypeOut, bool)) []TypeOut {
+	outputs, _ := ExecuteNWithStats(numOfRoutines, n, inputs, process)
+	return outputs
+}
+
+// ExecuteNWithStats works like ExecuteN, but it also returns the Stats of the execution, in which no input ever
+// fails, whether its output was kept or not.
+func ExecuteNWithStats[TypeIn any, TypeOut any](numOfRoutines, n int, inputs []TypeIn, process func(input TypeIn) (TypeOut, bool)) ([]TypeOut, Stats) {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	type result struct {
+		output TypeOut
+		keep   bool
+	}
+
+	if n <= 0 {
+		return []TypeOut{}, newStatsCounter(len(inputs)).stats()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	counter := newStatsCounter(len(inputs))
+	outputChannel := fanOut(ctx, numOfRoutines, inputs, func(_, index int, input TypeIn) result {
+		counter.process(index)
+		output, keep := process(input)
+		return result{output: output, keep: keep}
+	})
+
+	outputs := []TypeOut{}
+	for r := range outputChannel {
+		if !r.keep || len(outputs) == n {
+			continue
+		}
+		outputs = append(outputs, r.output)
+		if len(outputs) == n {
+			cancel()
+		}
+	}
+
+	return outputs, counter.stats()
+}
@@ -0,0 +1,74 @@
+package concurrent_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteN(t *testing.T) {
+	inputs := make([]int, 10000)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	testCases := []struct {
+		name          string
+		n             int
+		keep          func(int) bool
+		expectedLen   int
+		expectedCalls func(calls int64) bool
+	}{
+		{
+			name: "stops after n kept outputs",
+			n:    10,
+			keep: func(in int) bool {
+				return in%2 == 0
+			},
+			expectedLen: 10,
+			expectedCalls: func(calls int64) bool {
+				return calls < int64(len(inputs))
+			},
+		},
+		{
+			name: "returns everything when fewer than n qualify",
+			n:    10,
+			keep: func(in int) bool {
+				return in < 3
+			},
+			expectedLen: 3,
+			expectedCalls: func(calls int64) bool {
+				return calls == int64(len(inputs))
+			},
+		},
+		{
+			name: "non-positive n processes nothing",
+			n:    0,
+			keep: func(in int) bool {
+				return true
+			},
+			expectedLen: 0,
+			expectedCalls: func(calls int64) bool {
+				return calls == 0
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int64
+			outputs := ExecuteN(4, tc.n, inputs, func(in int) (int, bool) {
+				atomic.AddInt64(&calls, 1)
+				return in, tc.keep(in)
+			})
+
+			assert.Len(t, outputs, tc.expectedLen)
+			for _, o := range outputs {
+				assert.True(t, tc.keep(o))
+			}
+			assert.True(t, tc.expectedCalls(atomic.LoadInt64(&calls)))
+		})
+	}
+}
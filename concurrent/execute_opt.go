@@ -0,0 +1,136 @@
+package concurrent
+
+import "time"
+
+// Option tunes an execution of ExecuteOpt, see the With functions. Unlike the fields of Options, an Option does not
+// depend on the type of the inputs, so the options that need a function of the input, like Options.Skip, are only
+// available through ExecuteWithOptions.
+type Option func(s *settings)
+
+// settings holds what the Options passed to ExecuteOpt have set.
+type settings struct {
+	numOfRoutines   int
+	bufferSize      int
+	maxInFlight     int
+	minInterval     time.Duration
+	rateInterval    time.Duration
+	panicPolicy     PanicPolicy
+	distribution    DistributionStrategy
+	recorder        Recorder
+	watchdogTimeout time.Duration
+	label           string
+}
+
+// WithRoutines sets the number of goroutines, like the numOfRoutines parameter of Execute. DefaultRoutines is used
+// without it.
+func WithRoutines(numOfRoutines int) Option {
+	return func(s *settings) {
+		s.numOfRoutines = numOfRoutines
+	}
+}
+
+// WithBuffer sets the capacity of the channel the workers send their outputs to, see Options.BufferSize.
+func WithBuffer(bufferSize int) Option {
+	return func(s *settings) {
+		s.bufferSize = bufferSize
+	}
+}
+
+// WithMaxInFlight sets the maximum number of process calls running at the same time, see Options.MaxInFlight.
+func WithMaxInFlight(maxInFlight int) Option {
+	return func(s *settings) {
+		s.maxInFlight = maxInFlight
+	}
+}
+
+// WithMinInterval sets the minimum duration between the start of two process calls, see Options.MinInterval.
+func WithMinInterval(minInterval time.Duration) Option {
+	return func(s *settings) {
+		s.minInterval = minInterval
+	}
+}
+
+// WithRateLimit limits process to ratePerSec calls per second, which are spread evenly like ExecuteRateLimited does.
+// It is the same as WithMinInterval with a second divided by ratePerSec, and when both are given, the longer interval
+// is kept. A ratePerSec that is zero or negative does not limit anything.
+func WithRateLimit(ratePerSec int) Option {
+	return func(s *settings) {
+		s.rateInterval = 0
+		if ratePerSec > 0 {
+			s.rateInterval = time.Second / time.Duration(ratePerSec)
+		}
+	}
+}
+
+// WithPanicPolicy sets what happens when a process call panics, which is PanicPropagate without it. Since ExecuteOpt
+// returns no error, PanicRecoverAsError drops the input like PanicRecover does.
+func WithPanicPolicy(panicPolicy PanicPolicy) Option {
+	return func(s *settings) {
+		s.panicPolicy = panicPolicy
+	}
+}
+
+// WithDistribution sets how the inputs are distributed to the workers. Weighted needs the weight of every input, which
+// only Options.Weight could give, so it falls back to SharedQueue with ExecuteOpt.
+func WithDistribution(distribution DistributionStrategy) Option {
+	return func(s *settings) {
+		s.distribution = distribution
+	}
+}
+
+// WithRecorder sets the Recorder observing the duration of every process call, see Options.Recorder.
+func WithRecorder(recorder Recorder) Option {
+	return func(s *settings) {
+		s.recorder = recorder
+	}
+}
+
+// WithWatchdogTimeout sets the duration after which a stalled execution logs a warning, see Options.WatchdogTimeout.
+func WithWatchdogTimeout(watchdogTimeout time.Duration) Option {
+	return func(s *settings) {
+		s.watchdogTimeout = watchdogTimeout
+	}
+}
+
+// WithLabel sets the pprof label of the workers, see Options.Label.
+func WithLabel(label string) Option {
+	return func(s *settings) {
+		s.label = label
+	}
+}
+
+// ExecuteOpt works like Execute, with its execution tuned by opts, so that the features of ExecuteWithOptions could be
+// combined without a function for every combination. The Options are applied in order, so a later one overrides an
+// earlier one of the same kind. Without any Option, ExecuteOpt processes the inputs like Execute with DefaultRoutines,
+// except that a panic inside process is raised again on the calling goroutine as a *PanicError, instead of crashing the
+// program from the worker that panicked.
+func ExecuteOpt[TypeIn any, TypeOut any](inputs []TypeIn, process func(input TypeIn) TypeOut, opts ...Option) []TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	s := settings{panicPolicy: PanicPropagate}
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	// the rate limit and the min interval are kept apart, so that the longer one wins whatever order they are given in
+	minInterval := s.minInterval
+	if s.rateInterval > minInterval {
+		minInterval = s.rateInterval
+	}
+
+	outputs, _ := ExecuteWithOptions(s.numOfRoutines, inputs, func(input TypeIn) (TypeOut, error) {
+		return process(input), nil
+	}, Options[TypeIn]{
+		BufferSize:      s.bufferSize,
+		MaxInFlight:     s.maxInFlight,
+		PanicPolicy:     s.panicPolicy,
+		MinInterval:     minInterval,
+		Distribution:    s.distribution,
+		Recorder:        s.recorder,
+		WatchdogTimeout: s.watchdogTimeout,
+		Label:           s.label,
+	})
+	return outputs
+}
@@ -0,0 +1,97 @@
+package concurrent_test
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteOpt(t *testing.T) {
+	inputs := make([]int, 20)
+	for i := range inputs {
+		inputs[i] = i
+	}
+	double := func(in int) int {
+		return in * 2
+	}
+
+	t.Run("no option works like Execute", func(t *testing.T) {
+		assert.ElementsMatch(t, Execute(0, inputs, double), ExecuteOpt(inputs, double))
+	})
+
+	t.Run("no option raises a panic on the calling goroutine", func(t *testing.T) {
+		assert.Panics(t, func() {
+			ExecuteOpt(inputs, func(in int) int {
+				if in == 3 {
+					panic("unexpected input")
+				}
+				return in
+			})
+		})
+	})
+
+	t.Run("routines, max in flight and buffer", func(t *testing.T) {
+		var inFlight peakCounter
+		outputs := ExecuteOpt(inputs, func(in int) int {
+			inFlight.enter()
+			defer inFlight.exit()
+			time.Sleep(time.Millisecond)
+			return in
+		}, WithRoutines(4), WithMaxInFlight(2), WithBuffer(-1))
+		assert.ElementsMatch(t, inputs, outputs)
+		assert.Equal(t, int64(2), inFlight.max())
+	})
+
+	t.Run("routines, rate limit and panic policy", func(t *testing.T) {
+		const ratePerSec = 200
+		var mu sync.Mutex
+		startedAt := []time.Time{}
+		outputs := ExecuteOpt(inputs[:10], func(in int) int {
+			mu.Lock()
+			startedAt = append(startedAt, time.Now())
+			mu.Unlock()
+			if in == 0 {
+				panic("unexpected input")
+			}
+			return in
+		}, WithRoutines(4), WithRateLimit(ratePerSec), WithPanicPolicy(PanicRecover))
+
+		assert.ElementsMatch(t, inputs[1:10], outputs)
+		sort.Slice(startedAt, func(i, j int) bool {
+			return startedAt[i].Before(startedAt[j])
+		})
+		for i := 1; i < len(startedAt); i++ {
+			// with a little slack for the scheduling of the worker receiving the previous input
+			assert.GreaterOrEqual(t, startedAt[i].Sub(startedAt[i-1]), time.Second/ratePerSec-2*time.Millisecond)
+		}
+	})
+
+	t.Run("the longer of the rate limit and the min interval is kept", func(t *testing.T) {
+		// every combination spaces the 5 inputs 20ms apart, whichever option gives the longer interval and comes first
+		for _, opts := range [][]Option{
+			{WithMinInterval(20 * time.Millisecond), WithRateLimit(1000)},
+			{WithRateLimit(1000), WithMinInterval(20 * time.Millisecond)},
+			{WithMinInterval(time.Millisecond), WithRateLimit(50)},
+			{WithRateLimit(50), WithMinInterval(time.Millisecond)},
+		} {
+			start := time.Now()
+			ExecuteOpt(inputs[:5], double, append([]Option{WithRoutines(5)}, opts...)...)
+			assert.GreaterOrEqual(t, time.Since(start), 4*20*time.Millisecond)
+		}
+	})
+
+	t.Run("a later option overrides an earlier one", func(t *testing.T) {
+		var counter peakCounter
+		ExecuteOpt(inputs, func(in int) int {
+			counter.enter()
+			defer counter.exit()
+			time.Sleep(time.Millisecond)
+			return in
+		}, WithRoutines(8), WithRoutines(1))
+		assert.Equal(t, int64(1), counter.max())
+	})
+}
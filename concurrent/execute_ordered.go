@@ -0,0 +1,24 @@
+package concurrent
+
+import "context"
+
+// ExecuteOrdered works like Execute, but the output slice keeps the order of the inputs, i.e. the output at index i is
+// the result of processing the input at index i. The processing itself is still done concurrently by numOfRoutines
+// goroutines, each of them writes its outputs directly to their positions in the output slice.
+func ExecuteOrdered[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) TypeOut) []TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	outputs := make([]TypeOut, len(inputs))
+	done := fanOut(context.Background(), numOfRoutines, inputs, func(_, index int, input TypeIn) struct{} {
+		outputs[index] = process(input)
+		return struct{}{}
+	})
+
+	// wait for outputs
+	for range done {
+	}
+
+	return outputs
+}
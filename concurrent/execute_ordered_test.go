@@ -0,0 +1,49 @@
+package concurrent_test
+
+import (
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteOrdered(t *testing.T) {
+	inputs := []testInput{
+		{value: "value1"},
+		{value: "value2"},
+		{value: "value3"},
+		{value: valueForErrorCase},
+		{value: "value4"},
+		{value: "value5"},
+		{value: "value6"},
+	}
+	expectedOutputs := []testOutput{}
+	for _, i := range inputs {
+		expectedOutputs = append(expectedOutputs, testProcess(i))
+	}
+
+	testCases := []struct {
+		name          string
+		numOfRoutines int
+	}{
+		{
+			name:          "number of routines less than number of inputs",
+			numOfRoutines: 3,
+		},
+		{
+			name:          "number of routines equal number of inputs",
+			numOfRoutines: 7,
+		},
+		{
+			name:          "number of routines more than number of inputs",
+			numOfRoutines: 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			outputs := ExecuteOrdered(tc.numOfRoutines, inputs, testProcess)
+			assert.Equal(t, expectedOutputs, outputs)
+		})
+	}
+}
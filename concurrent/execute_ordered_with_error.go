@@ -0,0 +1,25 @@
+package concurrent
+
+// ExecuteOrderedWithError combines ExecuteOrdered and ExecuteWithError: both returned slices have the length of the
+// inputs slice, and their elements at index i belong to the input at index i. So outputs[i] is the output of a
+// successful input, in which case errs[i] is nil, and errs[i] is the *ItemError of a failed one, in which case
+// outputs[i] is the zero value. A panic inside process is recovered and reported as a *PanicError of that input.
+func ExecuteOrderedWithError[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) (TypeOut, error)) ([]TypeOut, []error) {
+	if process == nil && len(inputs) > 0 {
+		return nil, []error{ErrNilProcess}
+	}
+
+	outputs := make([]TypeOut, len(inputs))
+	errs := make([]error, len(inputs))
+	ExecuteIndexed(numOfRoutines, inputs, func(index int, input TypeIn) struct{} {
+		output, err := protect(process, input)
+		if err != nil {
+			errs[index] = &ItemError{Index: index, Err: err}
+		} else {
+			outputs[index] = output
+		}
+		return struct{}{}
+	})
+
+	return outputs, errs
+}
@@ -0,0 +1,46 @@
+package concurrent_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteOrderedWithError(t *testing.T) {
+	inputs := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	errOdd := errors.New("odd input")
+
+	outputs, errs := ExecuteOrderedWithError(3, inputs, func(in int) (int, error) {
+		if in == 7 {
+			panic("unexpected input")
+		}
+		if in%2 == 1 {
+			return in, errOdd
+		}
+		return in * 10, nil
+	})
+
+	assert.Len(t, outputs, len(inputs))
+	assert.Len(t, errs, len(inputs))
+	for i, in := range inputs {
+		if in%2 == 0 {
+			assert.NoError(t, errs[i])
+			assert.Equal(t, in*10, outputs[i])
+			continue
+		}
+
+		assert.Zero(t, outputs[i])
+		var itemErr *ItemError
+		if assert.True(t, errors.As(errs[i], &itemErr)) {
+			assert.Equal(t, i, itemErr.Index)
+		}
+		if in == 7 {
+			var panicErr *PanicError
+			assert.True(t, errors.As(errs[i], &panicErr))
+		} else {
+			assert.ErrorIs(t, errs[i], errOdd)
+		}
+	}
+}
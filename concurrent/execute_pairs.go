@@ -0,0 +1,29 @@
+package concurrent
+
+import (
+	"context"
+	"fmt"
+)
+
+// ExecutePairs works like Execute, but over two aligned slices: process is called with every pair of elements at the
+// same index in a and b. When a and b have different lengths, nothing is processed, and an error wrapping
+// ErrLengthMismatch is returned.
+func ExecutePairs[A any, B any, TypeOut any](numOfRoutines int, a []A, b []B, process func(a A, b B) TypeOut) ([]TypeOut, error) {
+	if len(a) != len(b) {
+		return nil, fmt.Errorf("%w: %d and %d", ErrLengthMismatch, len(a), len(b))
+	}
+	if process == nil && len(a) > 0 {
+		return nil, ErrNilProcess
+	}
+
+	outputChannel := fanOut(context.Background(), numOfRoutines, a, func(_, index int, input A) TypeOut {
+		return process(input, b[index])
+	})
+
+	outputs := make([]TypeOut, 0, len(a))
+	for o := range outputChannel {
+		outputs = append(outputs, o)
+	}
+
+	return outputs, nil
+}
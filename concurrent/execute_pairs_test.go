@@ -0,0 +1,33 @@
+package concurrent_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutePairs(t *testing.T) {
+	join := func(key string, value int) string {
+		return fmt.Sprintf("%s=%d", key, value)
+	}
+
+	t.Run("processes every aligned pair", func(t *testing.T) {
+		outputs, err := ExecutePairs(2, []string{"a", "b", "c"}, []int{1, 2, 3}, join)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"a=1", "b=2", "c=3"}, outputs)
+	})
+
+	t.Run("different lengths fails without processing", func(t *testing.T) {
+		calls := 0
+		outputs, err := ExecutePairs(2, []string{"a", "b"}, []int{1}, func(key string, value int) string {
+			calls++
+			return join(key, value)
+		})
+		assert.True(t, errors.Is(err, ErrLengthMismatch))
+		assert.Nil(t, outputs)
+		assert.Zero(t, calls)
+	})
+}
@@ -0,0 +1,35 @@
+package concurrent
+
+// ExecutePartition calls the predicate concurrently for every input, and splits the inputs into the ones that passed
+// and the ones that failed it, both keeping the order in which they appear in the inputs slice. An input whose
+// predicate returned an error, or panicked, is in neither of them, and its error is returned as an *ItemError instead,
+// with the errors sorted by the index of their input.
+func ExecutePartition[TypeIn any](numOfRoutines int, inputs []TypeIn, predicate func(input TypeIn) (bool, error)) (passed []TypeIn, failed []TypeIn, errs []error) {
+	if predicate == nil && len(inputs) > 0 {
+		return nil, nil, []error{ErrNilProcess}
+	}
+
+	type verdict struct {
+		passed bool
+		err    error
+	}
+
+	verdicts := ExecuteOrdered(numOfRoutines, inputs, func(input TypeIn) verdict {
+		ok, err := protect(predicate, input)
+		return verdict{passed: ok, err: err}
+	})
+
+	passed, failed, errs = []TypeIn{}, []TypeIn{}, []error{}
+	for i, v := range verdicts {
+		switch {
+		case v.err != nil:
+			errs = append(errs, &ItemError{Index: i, Err: v.err})
+		case v.passed:
+			passed = append(passed, inputs[i])
+		default:
+			failed = append(failed, inputs[i])
+		}
+	}
+
+	return passed, failed, errs
+}
@@ -0,0 +1,47 @@
+package concurrent_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutePartition(t *testing.T) {
+	inputs := []int{1, -2, 3, 0, -5, 6, 0}
+	errZero := errors.New("zero is neither positive nor negative")
+
+	passed, failed, errs := ExecutePartition(3, inputs, func(in int) (bool, error) {
+		if in == 0 {
+			return false, errZero
+		}
+		if in == 6 {
+			panic("unexpected input")
+		}
+		return in > 0, nil
+	})
+
+	assert.Equal(t, []int{1, 3}, passed)
+	assert.Equal(t, []int{-2, -5}, failed)
+	indexes := []int{}
+	for _, err := range errs {
+		var itemErr *ItemError
+		if assert.True(t, errors.As(err, &itemErr)) {
+			indexes = append(indexes, itemErr.Index)
+		}
+	}
+	assert.Equal(t, []int{3, 5, 6}, indexes)
+	assert.ErrorIs(t, errs[0], errZero)
+	var panicErr *PanicError
+	assert.True(t, errors.As(errs[1], &panicErr))
+
+	t.Run("empty inputs", func(t *testing.T) {
+		passed, failed, errs := ExecutePartition(3, []int{}, func(in int) (bool, error) {
+			return true, nil
+		})
+		assert.Empty(t, passed)
+		assert.Empty(t, failed)
+		assert.Empty(t, errs)
+	})
+}
@@ -0,0 +1,37 @@
+package concurrent
+
+import (
+	"context"
+	"sort"
+)
+
+// ExecutePriority works like Execute, but the inputs are handed to the workers by descending priority instead of in
+// the order of the inputs slice, the inputs of the same priority keeping their relative order. Only the order in which
+// the process calls start follows the priority: since the inputs are processed concurrently, a low-priority input
+// could still complete before a high-priority one that started earlier.
+func ExecutePriority[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, priority func(input TypeIn) int, process func(input TypeIn) TypeOut) []TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	priorities := make([]int, len(inputs))
+	order := make([]int, len(inputs))
+	for i, input := range inputs {
+		priorities[i] = priority(input)
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return priorities[order[i]] > priorities[order[j]]
+	})
+
+	outputChannel := fanOut(context.Background(), numOfRoutines, order, func(_, _ int, index int) TypeOut {
+		return process(inputs[index])
+	})
+
+	outputs := make([]TypeOut, 0, len(inputs))
+	for o := range outputChannel {
+		outputs = append(outputs, o)
+	}
+
+	return outputs
+}
@@ -0,0 +1,66 @@
+package concurrent_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutePriority(t *testing.T) {
+	// the inputs with a high priority are at the end
+	inputs := make([]int, 40)
+	for i := range inputs {
+		inputs[i] = i
+	}
+	priority := func(in int) int {
+		if in >= 30 {
+			return 10
+		}
+		return 0
+	}
+
+	testCases := []struct {
+		name          string
+		numOfRoutines int
+		// slack is how many of the first ten process calls to start may be low-priority ones, since the workers race
+		// to start their process calls
+		slack int
+	}{
+		{
+			name:          "single worker starts in priority order",
+			numOfRoutines: 1,
+			slack:         0,
+		},
+		{
+			name:          "multiple workers start the high priority inputs first",
+			numOfRoutines: 4,
+			slack:         3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			started := []int{}
+			outputs := ExecutePriority(tc.numOfRoutines, inputs, priority, func(in int) int {
+				mu.Lock()
+				started = append(started, in)
+				mu.Unlock()
+				time.Sleep(100 * time.Microsecond)
+				return in
+			})
+
+			assert.ElementsMatch(t, inputs, outputs)
+			for _, in := range started[:10-tc.slack] {
+				assert.GreaterOrEqual(t, in, 30)
+			}
+			if tc.slack == 0 {
+				// ties keep the order of the inputs
+				assert.Equal(t, []int{30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 0, 1}, started[:12])
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package concurrent
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ExecuteRateLimited works like Execute, but process is called at most ratePerSec times per second across all of the
+// numOfRoutines goroutines. The calls are spread evenly, i.e. two calls are always at least a second divided by
+// ratePerSec apart, so a burst of idle workers could never exceed the rate even momentarily. A ratePerSec that is zero
+// or negative means the calls are not limited at all.
+func ExecuteRateLimited[TypeIn any, TypeOut any](numOfRoutines int, ratePerSec int, inputs []TypeIn, process func(input TypeIn) TypeOut) []TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	limiter := newRateLimiter(ratePerSec)
+	outputChannel := fanOut(context.Background(), numOfRoutines, inputs, func(_, _ int, input TypeIn) TypeOut {
+		limiter.wait()
+		return process(input)
+	})
+
+	outputs := make([]TypeOut, 0, len(inputs))
+	for o := range outputChannel {
+		outputs = append(outputs, o)
+	}
+
+	return outputs
+}
+
+// rateLimiter spaces out the calls to wait so that consecutive calls return at least interval apart. A nil
+// rateLimiter does not limit anything.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newRateLimiter returns a rateLimiter allowing ratePerSec calls per second, or nil if ratePerSec is not positive.
+func newRateLimiter(ratePerSec int) *rateLimiter {
+	if ratePerSec <= 0 {
+		return nil
+	}
+	return newIntervalLimiter(time.Second / time.Duration(ratePerSec))
+}
+
+// newIntervalLimiter returns a rateLimiter spacing the calls interval apart, or nil if interval is not positive.
+func newIntervalLimiter(interval time.Duration) *rateLimiter {
+	if interval <= 0 {
+		return nil
+	}
+	return &rateLimiter{interval: interval}
+}
+
+// wait blocks until the caller is allowed to proceed.
+func (l *rateLimiter) wait() {
+	time.Sleep(l.reserve())
+}
+
+// waitContext works like wait, but gives up once ctx is done, and reports whether the caller is allowed to proceed,
+// which it is not once ctx is done.
+func (l *rateLimiter) waitContext(ctx context.Context) bool {
+	if delay := l.reserve(); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	}
+	return ctx.Err() == nil
+}
+
+// reserve reserves the next slot for the caller, and returns how long the caller has to wait for it.
+func (l *rateLimiter) reserve() time.Duration {
+	if l == nil {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	return delay
+}
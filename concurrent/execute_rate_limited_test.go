@@ -0,0 +1,65 @@
+package concurrent_test
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteRateLimited(t *testing.T) {
+	inputs := make([]int, 10)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	testCases := []struct {
+		name        string
+		ratePerSec  int
+		minDuration time.Duration
+		maxDuration time.Duration
+	}{
+		{
+			name:        "calls are spread according to the rate",
+			ratePerSec:  50,
+			minDuration: 9 * 20 * time.Millisecond,
+			maxDuration: time.Second,
+		},
+		{
+			name:        "zero rate means unlimited",
+			ratePerSec:  0,
+			minDuration: 0,
+			maxDuration: 100 * time.Millisecond,
+		},
+		{
+			name:        "negative rate means unlimited",
+			ratePerSec:  -1,
+			minDuration: 0,
+			maxDuration: 100 * time.Millisecond,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			calledAt := []time.Time{}
+			outputs := ExecuteRateLimited(5, tc.ratePerSec, inputs, func(in int) int {
+				mu.Lock()
+				calledAt = append(calledAt, time.Now())
+				mu.Unlock()
+				return in
+			})
+
+			assert.ElementsMatch(t, inputs, outputs)
+			sort.Slice(calledAt, func(i, j int) bool {
+				return calledAt[i].Before(calledAt[j])
+			})
+			elapsed := calledAt[len(calledAt)-1].Sub(calledAt[0])
+			assert.GreaterOrEqual(t, elapsed, tc.minDuration)
+			assert.Less(t, elapsed, tc.maxDuration)
+		})
+	}
+}
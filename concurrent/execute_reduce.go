@@ -0,0 +1,37 @@
+package concurrent
+
+import "context"
+
+// ExecuteReduce maps every input concurrently by calling the mapper function, and folds all mapped outputs into a
+// single value using the combiner function, starting from identity.
+//
+// The combiner MUST be associative and commutative, and identity must be its identity element (e.g. 0 for a sum, 1 for
+// a product), since the mapped outputs are combined in no particular order and identity is used more than once. Every
+// worker folds the outputs of its own inputs into a local accumulator seeded from identity, and the accumulators of
+// all workers are combined at the end, so there is no contention between the workers while folding.
+func ExecuteReduce[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, identity TypeOut, mapper func(input TypeIn) TypeOut, combiner func(a, b TypeOut) TypeOut) TypeOut {
+	if mapper == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	accumulators := make([]TypeOut, workerCount(numOfRoutines, len(inputs)))
+	for i := range accumulators {
+		accumulators[i] = identity
+	}
+
+	done := fanOut(context.Background(), numOfRoutines, inputs, func(worker, _ int, input TypeIn) struct{} {
+		accumulators[worker] = combiner(accumulators[worker], mapper(input))
+		return struct{}{}
+	})
+
+	// wait for all inputs to be folded
+	for range done {
+	}
+
+	output := identity
+	for _, acc := range accumulators {
+		output = combiner(output, acc)
+	}
+
+	return output
+}
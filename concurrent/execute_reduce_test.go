@@ -0,0 +1,57 @@
+package concurrent_test
+
+import (
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteReduce(t *testing.T) {
+	inputs := make([]int, 1000)
+	for i := range inputs {
+		inputs[i] = i + 1
+	}
+	square := func(in int) int {
+		return in * in
+	}
+	sum := func(a, b int) int {
+		return a + b
+	}
+	expectedSum := 0
+	for _, in := range inputs {
+		expectedSum += square(in)
+	}
+
+	testCases := []struct {
+		name          string
+		numOfRoutines int
+		inputs        []int
+		expected      int
+	}{
+		{
+			name:          "single routine",
+			numOfRoutines: 1,
+			inputs:        inputs,
+			expected:      expectedSum,
+		},
+		{
+			name:          "multiple routines",
+			numOfRoutines: 9,
+			inputs:        inputs,
+			expected:      expectedSum,
+		},
+		{
+			name:          "empty inputs returns identity",
+			numOfRoutines: 4,
+			inputs:        []int{},
+			expected:      0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ExecuteReduce(tc.numOfRoutines, tc.inputs, 0, square, sum))
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package concurrent
+
+import "context"
+
+// Result is what happened to a single input: the input itself, along with either the output of processing it, or the
+// error that occurred while doing so.
+type Result[TypeIn any, TypeOut any] struct {
+	Input  TypeIn
+	Output TypeOut
+	Err    error
+}
+
+// ExecuteResults works like ExecuteWithError, but every input is reported as a single Result carrying the input, and
+// either its output or its error, so there is no need to correlate the outputs and the errors back to the inputs. A
+// panic inside process is recovered and reported as a *PanicError in the Err field. Like Execute, the results are not
+// guaranteed to follow the input order.
+func ExecuteResults[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) (TypeOut, error)) []Result[TypeIn, TypeOut] {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	outputChannel := fanOut(context.Background(), numOfRoutines, inputs, func(_, _ int, input TypeIn) Result[TypeIn, TypeOut] {
+		output, err := protect(process, input)
+		return Result[TypeIn, TypeOut]{Input: input, Output: output, Err: err}
+	})
+
+	results := make([]Result[TypeIn, TypeOut], 0, len(inputs))
+	for r := range outputChannel {
+		results = append(results, r)
+	}
+
+	return results
+}
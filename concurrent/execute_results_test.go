@@ -0,0 +1,39 @@
+package concurrent_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteResults(t *testing.T) {
+	inputs := []testInput{
+		{value: "value1"},
+		{value: valueForErrorCase},
+		{value: "value2"},
+		{value: "panic"},
+	}
+
+	results := ExecuteResults(2, inputs, func(in testInput) (testOutput, error) {
+		if in.value == "panic" {
+			panic("cannot process")
+		}
+		return testProcessWithError(in)
+	})
+
+	assert.Len(t, results, len(inputs))
+	for _, r := range results {
+		switch r.Input.value {
+		case valueForErrorCase:
+			assert.Error(t, r.Err)
+		case "panic":
+			var panicErr *PanicError
+			assert.True(t, errors.As(r.Err, &panicErr))
+		default:
+			assert.NoError(t, r.Err)
+			assert.Equal(t, testProcess(r.Input), r.Output)
+		}
+	}
+}
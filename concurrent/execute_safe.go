@@ -0,0 +1,15 @@
+package concurrent
+
+// ExecuteSafe works like Execute, but a panic inside process is recovered instead of crashing the program. The outputs
+// of the inputs that are processed normally are gathered into the first returned slice, while every recovered panic is
+// gathered into the second one as an *ItemError wrapping a *PanicError, which carries the stack trace of the panic.
+// The remaining inputs keep being processed normally.
+func ExecuteSafe[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) TypeOut) ([]TypeOut, []error) {
+	if process == nil && len(inputs) > 0 {
+		return nil, []error{ErrNilProcess}
+	}
+
+	return ExecuteWithError(numOfRoutines, inputs, func(input TypeIn) (TypeOut, error) {
+		return process(input), nil
+	})
+}
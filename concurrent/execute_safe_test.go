@@ -0,0 +1,46 @@
+package concurrent_test
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteSafe(t *testing.T) {
+	inputs := make([]int, 30)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	outputs, errs := ExecuteSafe(4, inputs, func(in int) int {
+		if in%3 == 0 {
+			panic("cannot process multiples of three")
+		}
+		return in
+	})
+
+	expectedOutputs := []int{}
+	expectedIndexes := []int{}
+	for _, in := range inputs {
+		if in%3 == 0 {
+			expectedIndexes = append(expectedIndexes, in)
+			continue
+		}
+		expectedOutputs = append(expectedOutputs, in)
+	}
+	assert.ElementsMatch(t, expectedOutputs, outputs)
+
+	indexes := []int{}
+	for _, err := range errs {
+		var itemErr *ItemError
+		var panicErr *PanicError
+		if assert.True(t, errors.As(err, &itemErr)) && assert.True(t, errors.As(err, &panicErr)) {
+			indexes = append(indexes, itemErr.Index)
+			assert.Equal(t, "cannot process multiples of three", panicErr.Value)
+			assert.NotEmpty(t, panicErr.Stack)
+		}
+	}
+	assert.ElementsMatch(t, expectedIndexes, indexes)
+}
@@ -0,0 +1,78 @@
+package concurrent
+
+import "context"
+
+// ExecuteScan returns the running accumulation of the inputs, i.e. the output at index i is process called with the
+// output at index i-1, or identity for the first input, and the input at index i, like a running total. Since every
+// process call depends on the output of the previous one, the inputs are processed one at a time, in order, on the
+// calling goroutine. Use ExecuteParallelScan instead when process could be split into a map step and an associative
+// combine step, so that a large scan is not fully serial.
+func ExecuteScan[TypeIn any, TypeOut any](inputs []TypeIn, process func(prev TypeOut, input TypeIn) TypeOut, identity TypeOut) []TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	outputs := make([]TypeOut, len(inputs))
+	prev := identity
+	for i, input := range inputs {
+		prev = process(prev, input)
+		outputs[i] = prev
+	}
+
+	return outputs
+}
+
+// ExecuteParallelScan returns the same running accumulation as ExecuteScan with a process that combines prev with the
+// mapped input, i.e. the output at index i is combiner(output at index i-1, mapper(input at index i)), but most of the
+// work is spread over numOfRoutines goroutines. The inputs are split into one contiguous chunk per worker, every worker
+// maps and scans its own chunk, the totals of the chunks are then scanned on the calling goroutine, and every worker
+// finally combines the total of the chunks before its own into the outputs of its chunk.
+//
+// The combiner MUST be associative, since the outputs are combined in a different grouping than the sequential scan,
+// and identity must be its identity element (e.g. 0 for a sum, 1 for a product). Unlike ExecuteReduce, the combiner
+// needs not be commutative, since the outputs are always combined in the order of the inputs.
+func ExecuteParallelScan[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, identity TypeOut, mapper func(input TypeIn) TypeOut, combiner func(a, b TypeOut) TypeOut) []TypeOut {
+	if mapper == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	outputs := make([]TypeOut, len(inputs))
+	numOfWorkers := workerCount(numOfRoutines, len(inputs))
+	if numOfWorkers == 0 {
+		return outputs
+	}
+
+	// the output chunks share the backing array of outputs, so the workers write their outputs right in place
+	size := (len(inputs) + numOfWorkers - 1) / numOfWorkers
+	inputChunks, outputChunks := Chunk(inputs, size), Chunk(outputs, size)
+
+	done := fanOut(context.Background(), numOfWorkers, inputChunks, func(_, index int, chunk []TypeIn) struct{} {
+		acc := identity
+		for i, input := range chunk {
+			acc = combiner(acc, mapper(input))
+			outputChunks[index][i] = acc
+		}
+		return struct{}{}
+	})
+	for range done {
+	}
+
+	// offsets[i] is the total of the chunks before the chunk at index i
+	offsets := make([]TypeOut, len(outputChunks))
+	offsets[0] = identity
+	for i := 1; i < len(outputChunks); i++ {
+		previous := outputChunks[i-1]
+		offsets[i] = combiner(offsets[i-1], previous[len(previous)-1])
+	}
+
+	done = fanOut(context.Background(), numOfWorkers, outputChunks[1:], func(_, index int, chunk []TypeOut) struct{} {
+		for i := range chunk {
+			chunk[i] = combiner(offsets[index+1], chunk[i])
+		}
+		return struct{}{}
+	})
+	for range done {
+	}
+
+	return outputs
+}
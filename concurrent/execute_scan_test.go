@@ -0,0 +1,75 @@
+package concurrent_test
+
+import (
+	"strconv"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteScan(t *testing.T) {
+	t.Run("running totals", func(t *testing.T) {
+		outputs := ExecuteScan([]int{1, 2, 3, 4}, func(prev int, in int) int {
+			return prev + in
+		}, 0)
+		assert.Equal(t, []int{1, 3, 6, 10}, outputs)
+	})
+
+	t.Run("prev is identity for the first input", func(t *testing.T) {
+		outputs := ExecuteScan([]string{"a", "b"}, func(prev string, in string) string {
+			return prev + in
+		}, ">")
+		assert.Equal(t, []string{">a", ">ab"}, outputs)
+	})
+
+	t.Run("empty inputs", func(t *testing.T) {
+		assert.Empty(t, ExecuteScan([]int{}, func(prev int, in int) int {
+			return prev + in
+		}, 0))
+	})
+}
+
+func TestExecuteParallelScan(t *testing.T) {
+	inputs := make([]int, 1000)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	testCases := []struct {
+		name          string
+		numOfRoutines int
+		inputs        []int
+	}{
+		{name: "more inputs than goroutines", numOfRoutines: 7, inputs: inputs},
+		{name: "a single goroutine", numOfRoutines: 1, inputs: inputs},
+		{name: "more goroutines than inputs", numOfRoutines: 10, inputs: inputs[:3]},
+		{name: "empty inputs", numOfRoutines: 4, inputs: []int{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// joining strings is associative but not commutative, so the order of the combinations is checked too
+			mapper := strconv.Itoa
+			combiner := func(a, b string) string {
+				if a == "" || b == "" {
+					return a + b
+				}
+				return a + "," + b
+			}
+			expected := ExecuteScan(tc.inputs, func(prev string, in int) string {
+				return combiner(prev, mapper(in))
+			}, "")
+			assert.Equal(t, expected, ExecuteParallelScan(tc.numOfRoutines, tc.inputs, "", mapper, combiner))
+
+			sums := ExecuteParallelScan(tc.numOfRoutines, tc.inputs, 0, func(in int) int {
+				return in
+			}, func(a, b int) int {
+				return a + b
+			})
+			assert.Equal(t, ExecuteScan(tc.inputs, func(prev int, in int) int {
+				return prev + in
+			}, 0), sums)
+		})
+	}
+}
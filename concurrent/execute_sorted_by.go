@@ -0,0 +1,15 @@
+package concurrent
+
+import "sort"
+
+// ExecuteSortedBy works like Execute, but the outputs are sorted using the less function once all of them are
+// gathered, which gives a deterministic output slice without tying it to the input order like ExecuteOrdered does. The
+// sort is stable with regard to the order in which the outputs are gathered, so outputs that are neither less than
+// the other could still appear in any order among themselves.
+func ExecuteSortedBy[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) TypeOut, less func(a, b TypeOut) bool) []TypeOut {
+	outputs := Execute(numOfRoutines, inputs, process)
+	sort.SliceStable(outputs, func(i, j int) bool {
+		return less(outputs[i], outputs[j])
+	})
+	return outputs
+}
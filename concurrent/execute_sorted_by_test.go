@@ -0,0 +1,28 @@
+package concurrent_test
+
+import (
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteSortedBy(t *testing.T) {
+	inputs := []testInput{
+		{value: "value3"},
+		{value: "value1"},
+		{value: valueForErrorCase},
+		{value: "value2"},
+	}
+	byValue := func(a, b testOutput) bool {
+		return a.value < b.value
+	}
+
+	outputs := ExecuteSortedBy(4, inputs, testProcess, byValue)
+	assert.Equal(t, []testOutput{
+		testProcess(testInput{value: valueForErrorCase}),
+		testProcess(testInput{value: "value1"}),
+		testProcess(testInput{value: "value2"}),
+		testProcess(testInput{value: "value3"}),
+	}, outputs)
+}
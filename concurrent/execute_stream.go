@@ -0,0 +1,130 @@
+package concurrent
+
+import (
+	"sync"
+	"time"
+)
+
+// ExecuteStream works like Execute, but the inputs are read lazily from the inputs channel and the outputs are sent to
+// the returned channel as soon as they are processed, so multiple stages could be chained without gathering everything
+// in memory. The output channel is closed once the inputs channel is closed and every input read from it has been
+// processed. When the outputs are not consumed, the workers simply block on sending them and stop reading new inputs.
+func ExecuteStream[TypeIn any, TypeOut any](numOfRoutines int, inputs <-chan TypeIn, process func(input TypeIn) TypeOut) <-chan TypeOut {
+	if process == nil {
+		panic(ErrNilProcess)
+	}
+
+	outputChannel, _ := stream(numOfRoutines, inputs, nil, process)
+	return outputChannel
+}
+
+// ExecuteStreamWithIdleTimeout works like ExecuteStream, but it also gives up on the inputs channel once no input has
+// arrived for idleTimeout, which guards against a producer that hangs without ever closing its channel. So instead of
+// processing the inputs until the inputs channel is closed, it processes them until the inputs channel is closed or
+// stays idle for idleTimeout, whichever comes first, and then closes the output channel once the inputs already read
+// are processed. The idle time is measured from the last input handed to a worker, so the time spent waiting for a
+// free worker, because of slow process calls or a slow consumer of the outputs, does not count. An idleTimeout that is
+// zero or negative never times out.
+func ExecuteStreamWithIdleTimeout[TypeIn any, TypeOut any](numOfRoutines int, idleTimeout time.Duration, inputs <-chan TypeIn, process func(input TypeIn) TypeOut) <-chan TypeOut {
+	if process == nil {
+		panic(ErrNilProcess)
+	}
+	if idleTimeout <= 0 {
+		return ExecuteStream(numOfRoutines, inputs, process)
+	}
+
+	forwarded := make(chan TypeIn)
+	go func() {
+		defer close(forwarded)
+		idle := time.NewTimer(idleTimeout)
+		defer idle.Stop()
+		for {
+			select {
+			case input, ok := <-inputs:
+				if !ok {
+					return
+				}
+				forwarded <- input
+				// the timer may have fired while waiting for a worker, in which case its channel must be drained
+				if !idle.Stop() {
+					select {
+					case <-idle.C:
+					default:
+					}
+				}
+				idle.Reset(idleTimeout)
+			case <-idle.C:
+				return
+			}
+		}
+	}()
+
+	return ExecuteStream(numOfRoutines, forwarded, process)
+}
+
+// ExecuteCancelable works like ExecuteStream, but it also returns a stop function to end the execution early. Calling
+// stop makes the workers stop reading new inputs and drop the outputs they have not sent yet, waits for the process
+// calls in progress to return, and then closes the output channel. stop could be called any number of times, and from
+// any goroutine, including the one receiving the outputs.
+func ExecuteCancelable[TypeIn any, TypeOut any](numOfRoutines int, inputs <-chan TypeIn, process func(input TypeIn) TypeOut) (<-chan TypeOut, func()) {
+	if process == nil {
+		panic(ErrNilProcess)
+	}
+
+	stopChannel := make(chan struct{})
+	outputChannel, done := stream(numOfRoutines, inputs, stopChannel, process)
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			close(stopChannel)
+		})
+		<-done
+	}
+
+	return outputChannel, stop
+}
+
+// stream spawns numOfRoutines workers processing the inputs read from the inputs channel, until it is closed or
+// stopChannel is closed, whichever comes first. The returned done channel is closed right after the output channel.
+func stream[TypeIn any, TypeOut any](numOfRoutines int, inputs <-chan TypeIn, stopChannel <-chan struct{}, process func(input TypeIn) TypeOut) (<-chan TypeOut, <-chan struct{}) {
+	numOfRoutines = routinesOrDefault(numOfRoutines)
+	outputChannel := make(chan TypeOut)
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(numOfRoutines)
+
+	// spawn workers
+	for i := 0; i < numOfRoutines; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				var input TypeIn
+				var ok bool
+				select {
+				case input, ok = <-inputs:
+				case <-stopChannel:
+					return
+				}
+				if !ok {
+					return
+				}
+
+				select {
+				case outputChannel <- process(input):
+				case <-stopChannel:
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(outputChannel)
+		close(done)
+	}()
+
+	return outputChannel, done
+}
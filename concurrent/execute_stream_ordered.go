@@ -0,0 +1,63 @@
+package concurrent
+
+// ExecuteStreamOrdered works like ExecuteStream, but the outputs are sent to the returned channel in the order their
+// inputs were read from the inputs channel. An output that is processed before the outputs of the earlier inputs is
+// held in a reorder window until they are all sent, and the window holds up to twice as many inputs as there are
+// workers, counting the ones being processed. Once it is full, no new input is read until the output of the earliest
+// one is sent, so a single slow input stalls the whole stream, even when the other workers are free, in exchange for a
+// memory use that stays bounded however long the stream is.
+func ExecuteStreamOrdered[TypeIn any, TypeOut any](numOfRoutines int, inputs <-chan TypeIn, process func(input TypeIn) TypeOut) <-chan TypeOut {
+	if process == nil {
+		panic(ErrNilProcess)
+	}
+
+	type sequencedInput struct {
+		seq   int
+		input TypeIn
+	}
+	type sequencedOutput struct {
+		seq    int
+		output TypeOut
+	}
+
+	window := 2 * routinesOrDefault(numOfRoutines)
+	// a slot is taken for every input read, and given back once its output is sent
+	slots := make(chan struct{}, window)
+
+	sequenced := make(chan sequencedInput)
+	go func() {
+		defer close(sequenced)
+		seq := 0
+		for input := range inputs {
+			slots <- struct{}{}
+			sequenced <- sequencedInput{seq: seq, input: input}
+			seq++
+		}
+	}()
+
+	processed := ExecuteStream(numOfRoutines, sequenced, func(in sequencedInput) sequencedOutput {
+		return sequencedOutput{seq: in.seq, output: process(in.input)}
+	})
+
+	outputChannel := make(chan TypeOut)
+	go func() {
+		defer close(outputChannel)
+		pending := make(map[int]TypeOut, window)
+		next := 0
+		for o := range processed {
+			pending[o.seq] = o.output
+			for {
+				output, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				outputChannel <- output
+				<-slots
+				next++
+			}
+		}
+	}()
+
+	return outputChannel
+}
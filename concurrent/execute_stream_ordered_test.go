@@ -0,0 +1,63 @@
+package concurrent_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteStreamOrdered(t *testing.T) {
+	t.Run("outputs keep the order of the inputs when the processing times are reversed", func(t *testing.T) {
+		inputs := make([]int, 16)
+		for i := range inputs {
+			inputs[i] = i
+		}
+
+		outputs := drain(ExecuteStreamOrdered(4, generate(inputs...), func(in int) int {
+			time.Sleep(time.Duration(len(inputs)-in) * time.Millisecond)
+			return in * 2
+		}))
+
+		expected := make([]int, len(inputs))
+		for i := range expected {
+			expected[i] = i * 2
+		}
+		assert.Equal(t, expected, outputs)
+	})
+
+	t.Run("the reorder window bounds the inputs read ahead", func(t *testing.T) {
+		inputs := make(chan int)
+		release := make(chan struct{})
+		outputs := ExecuteStreamOrdered(2, inputs, func(in int) int {
+			if in == 0 {
+				<-release
+			}
+			return in
+		})
+
+		// with 2 workers, the window holds 4 inputs, the first of which is blocked, and a fifth one is read before
+		// waiting for room in the window
+		read := 0
+		for sending := true; sending; {
+			select {
+			case inputs <- read:
+				read++
+			case <-time.After(50 * time.Millisecond):
+				sending = false
+			}
+		}
+		assert.Equal(t, 5, read)
+
+		close(release)
+		close(inputs)
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, drain(outputs))
+	})
+
+	t.Run("empty input closes the output", func(t *testing.T) {
+		assert.Empty(t, drain(ExecuteStreamOrdered(2, generate[int](), func(in int) int {
+			return in
+		})))
+	})
+}
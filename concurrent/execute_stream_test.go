@@ -0,0 +1,143 @@
+package concurrent_test
+
+import (
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+// generate returns a channel that emits the given values and is closed afterwards.
+func generate[T any](values ...T) <-chan T {
+	channel := make(chan T)
+	go func() {
+		defer close(channel)
+		for _, v := range values {
+			channel <- v
+		}
+	}()
+	return channel
+}
+
+// drain reads every value from channel until it is closed.
+func drain[T any](channel <-chan T) []T {
+	values := []T{}
+	for v := range channel {
+		values = append(values, v)
+	}
+	return values
+}
+
+func TestExecuteStream(t *testing.T) {
+	t.Run("all inputs are processed", func(t *testing.T) {
+		outputs := drain(ExecuteStream(3, generate(1, 2, 3, 4, 5), func(in int) int {
+			return in * 2
+		}))
+		assert.ElementsMatch(t, []int{2, 4, 6, 8, 10}, outputs)
+	})
+
+	t.Run("empty inputs closes the outputs", func(t *testing.T) {
+		outputs := drain(ExecuteStream(3, generate[int](), func(in int) int {
+			return in
+		}))
+		assert.Empty(t, outputs)
+	})
+
+	t.Run("stages could be chained", func(t *testing.T) {
+		doubled := ExecuteStream(2, generate(1, 2, 3), func(in int) int {
+			return in * 2
+		})
+		formatted := ExecuteStream(2, doubled, strconv.Itoa)
+		assert.ElementsMatch(t, []string{"2", "4", "6"}, drain(formatted))
+	})
+}
+
+func TestExecuteStreamWithIdleTimeout(t *testing.T) {
+	t.Run("a producer pausing beyond the idle timeout ends the stream", func(t *testing.T) {
+		inputs := make(chan int)
+		resume := make(chan struct{})
+		go func() {
+			inputs <- 1
+			inputs <- 2
+			// hang without closing, until the test is over
+			<-resume
+		}()
+		defer close(resume)
+
+		start := time.Now()
+		outputs := drain(ExecuteStreamWithIdleTimeout(2, 20*time.Millisecond, inputs, func(in int) int {
+			return in * 2
+		}))
+		assert.ElementsMatch(t, []int{2, 4}, outputs)
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("pauses shorter than the idle timeout are waited for", func(t *testing.T) {
+		inputs := make(chan int)
+		go func() {
+			defer close(inputs)
+			for i := 0; i < 3; i++ {
+				time.Sleep(5 * time.Millisecond)
+				inputs <- i
+			}
+		}()
+
+		outputs := drain(ExecuteStreamWithIdleTimeout(2, 100*time.Millisecond, inputs, func(in int) int {
+			return in
+		}))
+		assert.ElementsMatch(t, []int{0, 1, 2}, outputs)
+	})
+
+	t.Run("slow process calls do not count as idle", func(t *testing.T) {
+		outputs := drain(ExecuteStreamWithIdleTimeout(1, 10*time.Millisecond, generate(1, 2, 3), func(in int) int {
+			time.Sleep(20 * time.Millisecond)
+			return in
+		}))
+		assert.ElementsMatch(t, []int{1, 2, 3}, outputs)
+	})
+}
+
+func TestExecuteCancelable(t *testing.T) {
+	t.Run("processes everything when not stopped", func(t *testing.T) {
+		outputChannel, stop := ExecuteCancelable(3, generate(1, 2, 3), func(in int) int {
+			return in
+		})
+		assert.ElementsMatch(t, []int{1, 2, 3}, drain(outputChannel))
+		stop()
+	})
+
+	t.Run("stop ends an endless stream", func(t *testing.T) {
+		goroutinesBefore := runtime.NumGoroutine()
+		inputs := make(chan int)
+		go func() {
+			for i := 0; ; i++ {
+				select {
+				case inputs <- i:
+				case <-time.After(time.Second):
+					return
+				}
+			}
+		}()
+
+		outputChannel, stop := ExecuteCancelable(3, inputs, func(in int) int {
+			return in
+		})
+		received := 0
+		for range outputChannel {
+			received++
+			if received == 10 {
+				stop()
+				stop()
+			}
+		}
+		assert.GreaterOrEqual(t, received, 10)
+
+		_, ok := <-outputChannel
+		assert.False(t, ok)
+		assertNoGoroutineLeak(t, goroutinesBefore+1)
+	})
+}
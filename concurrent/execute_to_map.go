@@ -0,0 +1,23 @@
+package concurrent
+
+import "context"
+
+// ExecuteToMap works like Execute, but the outputs are gathered into a map, under the key returned by keyFunc for
+// every output. When multiple outputs share the same key, the last one gathered wins, and since the outputs are
+// gathered in no particular order, which one that is could differ from one execution to the next.
+func ExecuteToMap[TypeIn any, TypeOut any, K comparable](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) TypeOut, keyFunc func(output TypeOut) K) map[K]TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	outputChannel := fanOut(context.Background(), numOfRoutines, inputs, func(_, _ int, input TypeIn) TypeOut {
+		return process(input)
+	})
+
+	outputs := make(map[K]TypeOut, len(inputs))
+	for o := range outputChannel {
+		outputs[keyFunc(o)] = o
+	}
+
+	return outputs
+}
@@ -0,0 +1,41 @@
+package concurrent_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+type testUser struct {
+	id   int
+	name string
+}
+
+func TestExecuteToMap(t *testing.T) {
+	ids := []int{1, 2, 3, 4, 5}
+	fetchUser := func(id int) testUser {
+		return testUser{id: id, name: fmt.Sprintf("user%d", id)}
+	}
+	byID := func(user testUser) int {
+		return user.id
+	}
+
+	users := ExecuteToMap(3, ids, fetchUser, byID)
+	assert.Len(t, users, len(ids))
+	for _, id := range ids {
+		assert.Equal(t, fetchUser(id), users[id])
+	}
+}
+
+func TestExecuteToMapDuplicateKeys(t *testing.T) {
+	users := ExecuteToMap(3, []int{1, 2, 3, 4}, func(id int) testUser {
+		return testUser{id: id % 2, name: fmt.Sprintf("user%d", id)}
+	}, func(user testUser) int {
+		return user.id
+	})
+	assert.Len(t, users, 2)
+	assert.Contains(t, []string{"user2", "user4"}, users[0].name)
+	assert.Contains(t, []string{"user1", "user3"}, users[1].name)
+}
@@ -0,0 +1,34 @@
+package concurrent
+
+import "context"
+
+// ExecuteToSink works like Execute, but instead of gathering every output into a single slice, the outputs are handed
+// to the sink function in chunks of flushSize outputs, the last chunk holding whatever outputs remain, so the memory
+// held by the execution stays bounded regardless of the number of inputs. A flushSize that is zero or negative hands
+// every output to sink on its own. sink is only ever called from a single goroutine, one chunk at a time, so it does
+// not need to be safe for concurrent use, and it could keep the chunks it receives since every chunk is a new slice.
+func ExecuteToSink[TypeIn any, TypeOut any](numOfRoutines, flushSize int, inputs []TypeIn, process func(input TypeIn) TypeOut, sink func(outputs []TypeOut)) {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	if flushSize <= 0 {
+		flushSize = 1
+	}
+
+	outputChannel := fanOut(context.Background(), numOfRoutines, inputs, func(_, _ int, input TypeIn) TypeOut {
+		return process(input)
+	})
+
+	outputs := make([]TypeOut, 0, flushSize)
+	for o := range outputChannel {
+		outputs = append(outputs, o)
+		if len(outputs) == flushSize {
+			sink(outputs)
+			outputs = make([]TypeOut, 0, flushSize)
+		}
+	}
+	if len(outputs) > 0 {
+		sink(outputs)
+	}
+}
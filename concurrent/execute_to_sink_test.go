@@ -0,0 +1,68 @@
+package concurrent_test
+
+import (
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteToSink(t *testing.T) {
+	inputs := make([]int, 10)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	testCases := []struct {
+		name          string
+		flushSize     int
+		expectedSizes []int
+	}{
+		{
+			name:          "flush size divides the outputs",
+			flushSize:     5,
+			expectedSizes: []int{5, 5},
+		},
+		{
+			name:          "last chunk is partial",
+			flushSize:     4,
+			expectedSizes: []int{4, 4, 2},
+		},
+		{
+			name:          "flush size larger than the outputs",
+			flushSize:     100,
+			expectedSizes: []int{10},
+		},
+		{
+			name:          "non-positive flush size flushes every output",
+			flushSize:     0,
+			expectedSizes: []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sizes := []int{}
+			outputs := []int{}
+			ExecuteToSink(3, tc.flushSize, inputs, func(in int) int {
+				return in
+			}, func(chunk []int) {
+				sizes = append(sizes, len(chunk))
+				outputs = append(outputs, chunk...)
+			})
+
+			assert.Equal(t, tc.expectedSizes, sizes)
+			assert.ElementsMatch(t, inputs, outputs)
+		})
+	}
+}
+
+func TestExecuteToSinkEmptyInputs(t *testing.T) {
+	calls := 0
+	ExecuteToSink(3, 4, []int{}, func(in int) int {
+		return in
+	}, func(chunk []int) {
+		calls++
+	})
+	assert.Zero(t, calls)
+}
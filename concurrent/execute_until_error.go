@@ -0,0 +1,58 @@
+package concurrent
+
+import (
+	"context"
+	"sync"
+)
+
+// ExecuteUntilError works like ExecuteWithError, but the execution fails fast: as soon as a process call returns an
+// error, no more inputs are handed to the workers. The inputs that are already being processed at that moment are
+// allowed to complete, and ExecuteUntilError then returns the outputs gathered so far along with the first error, as an
+// *ItemError holding the index of the failed input. The error is nil when every input is processed successfully.
+func ExecuteUntilError[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) (TypeOut, error)) ([]TypeOut, error) {
+	outputs, _, err := ExecuteUntilErrorWithStats(numOfRoutines, inputs, process)
+	return outputs, err
+}
+
+// ExecuteUntilErrorWithStats works like ExecuteUntilError, but it also returns the Stats of the execution, in which
+// the failed inputs are the ones whose process call returned an error, which could be more than one when several
+// process calls fail at the same time.
+func ExecuteUntilErrorWithStats[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) (TypeOut, error)) ([]TypeOut, Stats, error) {
+	if process == nil && len(inputs) > 0 {
+		return nil, Stats{}, ErrNilProcess
+	}
+
+	type result struct {
+		output TypeOut
+		err    error
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var once sync.Once
+	var firstErr error
+	counter := newStatsCounter(len(inputs))
+	outputChannel := fanOut(ctx, numOfRoutines, inputs, func(_, index int, input TypeIn) result {
+		counter.process(index)
+		output, err := protect(process, input)
+		if err != nil {
+			counter.fail()
+			once.Do(func() {
+				firstErr = &ItemError{Index: index, Err: err}
+				cancel()
+			})
+			return result{err: err}
+		}
+		return result{output: output}
+	})
+
+	outputs := make([]TypeOut, 0, len(inputs))
+	for r := range outputChannel {
+		if r.err == nil {
+			outputs = append(outputs, r.output)
+		}
+	}
+
+	return outputs, counter.stats(), firstErr
+}
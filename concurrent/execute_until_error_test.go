@@ -0,0 +1,45 @@
+package concurrent_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteUntilError(t *testing.T) {
+	inputs := make([]int, 1000)
+	for i := range inputs {
+		inputs[i] = i
+	}
+	cause := errors.New("cause")
+
+	t.Run("stops distributing inputs after the first error", func(t *testing.T) {
+		var calls int64
+		outputs, err := ExecuteUntilError(2, inputs, func(in int) (int, error) {
+			atomic.AddInt64(&calls, 1)
+			if in == 10 {
+				return 0, cause
+			}
+			return in, nil
+		})
+
+		var itemErr *ItemError
+		assert.True(t, errors.Is(err, cause))
+		if assert.True(t, errors.As(err, &itemErr)) {
+			assert.Equal(t, 10, itemErr.Index)
+		}
+		assert.Less(t, atomic.LoadInt64(&calls), int64(len(inputs)))
+		assert.Equal(t, int(atomic.LoadInt64(&calls))-1, len(outputs))
+	})
+
+	t.Run("returns every output when nothing fails", func(t *testing.T) {
+		outputs, err := ExecuteUntilError(4, inputs, func(in int) (int, error) {
+			return in, nil
+		})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, inputs, outputs)
+	})
+}
@@ -0,0 +1,62 @@
+package concurrent
+
+import (
+	"context"
+	"sort"
+)
+
+// ExecuteWeighted works like Execute, but the inputs are assigned to the workers up front according to their weight,
+// which should be proportional to how long processing them takes, so that every worker gets roughly the same total
+// weight. The assignment is greedy: starting from the heaviest input, every input goes to the worker with the lowest
+// total weight so far, and every worker processes its inputs from the heaviest to the lightest. This keeps a few heavy
+// inputs from landing at the end of the execution, where they would leave the other workers idle.
+func ExecuteWeighted[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, weight func(input TypeIn) int, process func(input TypeIn) TypeOut) []TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	config := fanOutConfig{
+		assign: func(numOfWorkers int) [][]int {
+			return assignByWeight(numOfWorkers, inputs, weight)
+		},
+	}
+	outputChannel := fanOutWith(context.Background(), numOfRoutines, inputs, config, func(_, _ int, input TypeIn) TypeOut {
+		return process(input)
+	})
+
+	outputs := make([]TypeOut, 0, len(inputs))
+	for o := range outputChannel {
+		outputs = append(outputs, o)
+	}
+
+	return outputs
+}
+
+// assignByWeight assigns the indexes of the inputs to numOfWorkers workers using the longest-processing-time-first
+// rule, see ExecuteWeighted.
+func assignByWeight[TypeIn any](numOfWorkers int, inputs []TypeIn, weight func(input TypeIn) int) [][]int {
+	weights := make([]int, len(inputs))
+	order := make([]int, len(inputs))
+	for i, input := range inputs {
+		weights[i] = weight(input)
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return weights[order[i]] > weights[order[j]]
+	})
+
+	assigned := make([][]int, numOfWorkers)
+	loads := make([]int, numOfWorkers)
+	for _, index := range order {
+		lightest := 0
+		for w := 1; w < numOfWorkers; w++ {
+			if loads[w] < loads[lightest] {
+				lightest = w
+			}
+		}
+		assigned[lightest] = append(assigned[lightest], index)
+		loads[lightest] += weights[index]
+	}
+
+	return assigned
+}
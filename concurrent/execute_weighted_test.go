@@ -0,0 +1,76 @@
+package concurrent_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func durationWeight(d time.Duration) int {
+	return int(d / time.Millisecond)
+}
+
+func TestExecuteWeighted(t *testing.T) {
+	inputs := []time.Duration{
+		time.Millisecond,
+		2 * time.Millisecond,
+		time.Millisecond,
+		20 * time.Millisecond,
+		3 * time.Millisecond,
+		0,
+	}
+
+	testCases := []struct {
+		name          string
+		numOfRoutines int
+	}{
+		{
+			name:          "single routine",
+			numOfRoutines: 1,
+		},
+		{
+			name:          "multiple routines",
+			numOfRoutines: 3,
+		},
+		{
+			name:          "more routines than inputs",
+			numOfRoutines: 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			outputs := ExecuteWeighted(tc.numOfRoutines, inputs, durationWeight, sleepFor)
+			assert.ElementsMatch(t, inputs, outputs)
+		})
+	}
+}
+
+// heavyTailInputs returns light inputs followed by a few heavy ones at the very end, which a shared queue only picks
+// up after every light input is processed.
+func heavyTailInputs() []time.Duration {
+	inputs := []time.Duration{}
+	for i := 0; i < 30; i++ {
+		inputs = append(inputs, 6*time.Millisecond)
+	}
+	for i := 0; i < 3; i++ {
+		inputs = append(inputs, 60*time.Millisecond)
+	}
+	return inputs
+}
+
+func BenchmarkExecuteWeightedHeavyTail(b *testing.B) {
+	inputs := heavyTailInputs()
+	for i := 0; i < b.N; i++ {
+		ExecuteWeighted(4, inputs, durationWeight, sleepFor)
+	}
+}
+
+func BenchmarkExecuteHeavyTail(b *testing.B) {
+	inputs := heavyTailInputs()
+	for i := 0; i < b.N; i++ {
+		Execute(4, inputs, sleepFor)
+	}
+}
@@ -0,0 +1,36 @@
+package concurrent
+
+import "sync/atomic"
+
+// ExecuteWithCircuitBreaker works like ExecuteWithError, but it stops hammering a failing dependency: once threshold
+// process calls in a row have failed, across all workers, the circuit breaker trips, and every input that is not yet
+// processed is skipped and reported as an *ItemError wrapping ErrCircuitOpen. A successful process call resets the
+// count of failures in a row. A threshold that is zero or negative never trips the circuit breaker.
+func ExecuteWithCircuitBreaker[TypeIn any, TypeOut any](numOfRoutines int, threshold int, inputs []TypeIn, process func(input TypeIn) (TypeOut, error)) ([]TypeOut, []error) {
+	if process == nil && len(inputs) > 0 {
+		return nil, []error{ErrNilProcess}
+	}
+
+	if threshold <= 0 {
+		return ExecuteWithError(numOfRoutines, inputs, process)
+	}
+
+	var consecutiveFailures int64
+	var open int32
+	return ExecuteWithError(numOfRoutines, inputs, func(input TypeIn) (TypeOut, error) {
+		if atomic.LoadInt32(&open) == 1 {
+			var zero TypeOut
+			return zero, ErrCircuitOpen
+		}
+
+		output, err := protect(process, input)
+		if err != nil {
+			if atomic.AddInt64(&consecutiveFailures, 1) >= int64(threshold) {
+				atomic.StoreInt32(&open, 1)
+			}
+			return output, err
+		}
+		atomic.StoreInt64(&consecutiveFailures, 0)
+		return output, nil
+	})
+}
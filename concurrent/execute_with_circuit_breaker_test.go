@@ -0,0 +1,69 @@
+package concurrent_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteWithCircuitBreaker(t *testing.T) {
+	inputs := make([]int, 100)
+	for i := range inputs {
+		inputs[i] = i
+	}
+	cause := errors.New("dependency is down")
+
+	t.Run("trips after threshold failures in a row", func(t *testing.T) {
+		var calls int64
+		outputs, errs := ExecuteWithCircuitBreaker(1, 3, inputs, func(in int) (int, error) {
+			atomic.AddInt64(&calls, 1)
+			if in >= 10 {
+				return 0, cause
+			}
+			return in, nil
+		})
+
+		assert.Len(t, outputs, 10)
+		assert.Equal(t, int64(13), atomic.LoadInt64(&calls))
+		assert.Len(t, errs, 90)
+
+		open := 0
+		for _, err := range errs {
+			var itemErr *ItemError
+			assert.True(t, errors.As(err, &itemErr))
+			if errors.Is(err, ErrCircuitOpen) {
+				open++
+				assert.GreaterOrEqual(t, itemErr.Index, 13)
+			} else {
+				assert.True(t, errors.Is(err, cause))
+			}
+		}
+		assert.Equal(t, 87, open)
+	})
+
+	t.Run("successes reset the failures in a row", func(t *testing.T) {
+		outputs, errs := ExecuteWithCircuitBreaker(1, 2, inputs, func(in int) (int, error) {
+			if in%2 == 0 {
+				return 0, cause
+			}
+			return in, nil
+		})
+		assert.Len(t, outputs, 50)
+		for _, err := range errs {
+			assert.False(t, errors.Is(err, ErrCircuitOpen))
+		}
+	})
+
+	t.Run("concurrent workers still trip the breaker", func(t *testing.T) {
+		var calls int64
+		_, errs := ExecuteWithCircuitBreaker(4, 5, inputs, func(in int) (int, error) {
+			atomic.AddInt64(&calls, 1)
+			return 0, cause
+		})
+		assert.Len(t, errs, len(inputs))
+		assert.Less(t, atomic.LoadInt64(&calls), int64(len(inputs)))
+	})
+}
@@ -0,0 +1,32 @@
+package concurrent
+
+import (
+	"context"
+	"time"
+)
+
+// ExecuteWithDeadline works like Execute, but the whole execution is given deadline to complete. Once it has elapsed,
+// no new inputs are handed to the workers, and ExecuteWithDeadline returns as soon as the process calls still running
+// return, with only the outputs of the process calls that returned within deadline, like ExecuteContext does on
+// cancellation. The returned bool reports whether every input was processed within deadline. A deadline that is zero
+// or negative has always elapsed, so nothing is processed.
+func ExecuteWithDeadline[TypeIn any, TypeOut any](deadline time.Duration, numOfRoutines int, inputs []TypeIn, process func(input TypeIn) TypeOut) ([]TypeOut, bool) {
+	outputs, _, completed := ExecuteWithDeadlineWithStats(deadline, numOfRoutines, inputs, process)
+	return outputs, completed
+}
+
+// ExecuteWithDeadlineWithStats works like ExecuteWithDeadline, but it also returns the Stats of the execution, in
+// which the failed inputs are the ones whose process call returned after deadline had elapsed.
+func ExecuteWithDeadlineWithStats[TypeIn any, TypeOut any](deadline time.Duration, numOfRoutines int, inputs []TypeIn, process func(input TypeIn) TypeOut) ([]TypeOut, Stats, bool) {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), deadline)
+	defer cancel()
+
+	outputs, stats, err := ExecuteContextWithStats(ctx, numOfRoutines, inputs, func(_ context.Context, input TypeIn) TypeOut {
+		return process(input)
+	})
+	return outputs, stats, err == nil
+}
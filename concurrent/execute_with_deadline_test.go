@@ -0,0 +1,41 @@
+package concurrent_test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteWithDeadline(t *testing.T) {
+	inputs := make([]time.Duration, 40)
+	for i := range inputs {
+		inputs[i] = 10 * time.Millisecond
+	}
+
+	t.Run("partial outputs are returned when the deadline elapses", func(t *testing.T) {
+		goroutinesBefore := runtime.NumGoroutine()
+		start := time.Now()
+		outputs, completed := ExecuteWithDeadline(35*time.Millisecond, 4, inputs, sleepFor)
+
+		assert.False(t, completed)
+		assert.NotEmpty(t, outputs)
+		assert.Less(t, len(outputs), len(inputs))
+		assert.Less(t, time.Since(start), 100*time.Millisecond)
+		assertNoGoroutineLeak(t, goroutinesBefore)
+	})
+
+	t.Run("every output is returned within the deadline", func(t *testing.T) {
+		outputs, completed := ExecuteWithDeadline(time.Second, 4, inputs, sleepFor)
+		assert.True(t, completed)
+		assert.Len(t, outputs, len(inputs))
+	})
+
+	t.Run("empty inputs complete", func(t *testing.T) {
+		outputs, completed := ExecuteWithDeadline(time.Second, 4, []time.Duration{}, sleepFor)
+		assert.True(t, completed)
+		assert.Empty(t, outputs)
+	})
+}
@@ -0,0 +1,11 @@
+package concurrent
+
+// ExecuteWithError works like Execute, but the process function could also return an error for an input. Outputs of
+// the inputs that are processed successfully are gathered into the first returned slice, while every failure is
+// gathered into the second one as an *ItemError holding the index of the failed input. Outputs of failed inputs are
+// omitted instead of being zero-valued. A panic inside process is recovered and reported as a *PanicError of that
+// input, so the remaining inputs are still processed. Like Execute, neither slice is guaranteed to follow the input
+// order.
+func ExecuteWithError[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) (TypeOut, error)) ([]TypeOut, []error) {
+	return ExecuteWithOptions(numOfRoutines, inputs, process, Options[TypeIn]{})
+}
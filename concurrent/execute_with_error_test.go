@@ -0,0 +1,82 @@
+package concurrent_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func testProcessWithError(in testInput) (testOutput, error) {
+	out := testProcess(in)
+	if out.err != nil {
+		return testOutput{}, out.err
+	}
+	return out, nil
+}
+
+func TestExecuteWithError(t *testing.T) {
+	inputs := []testInput{
+		{value: "value1"},
+		{value: valueForErrorCase},
+		{value: "value2"},
+		{value: "value3"},
+		{value: valueForErrorCase},
+	}
+
+	testCases := []struct {
+		name          string
+		numOfRoutines int
+		process       func(testInput) (testOutput, error)
+		expectedCount int
+		expectedIndex []int
+	}{
+		{
+			name:          "errors are reported with the index of the failed input",
+			numOfRoutines: 2,
+			process:       testProcessWithError,
+			expectedCount: 3,
+			expectedIndex: []int{1, 4},
+		},
+		{
+			name:          "panics are recovered and reported as errors",
+			numOfRoutines: 3,
+			process: func(in testInput) (testOutput, error) {
+				if in.value == valueForErrorCase {
+					panic("unexpected input")
+				}
+				return testProcessWithError(in)
+			},
+			expectedCount: 3,
+			expectedIndex: []int{1, 4},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			outputs, errs := ExecuteWithError(tc.numOfRoutines, inputs, tc.process)
+			assert.Len(t, outputs, tc.expectedCount)
+			for _, o := range outputs {
+				assert.NotEmpty(t, o.value)
+			}
+
+			indexes := []int{}
+			for _, err := range errs {
+				var itemErr *ItemError
+				if assert.True(t, errors.As(err, &itemErr)) {
+					indexes = append(indexes, itemErr.Index)
+				}
+			}
+			assert.ElementsMatch(t, tc.expectedIndex, indexes)
+		})
+	}
+}
+
+func TestItemError(t *testing.T) {
+	cause := errors.New("cause")
+	err := &ItemError{Index: 3, Err: cause}
+	assert.True(t, errors.Is(err, cause))
+	assert.Equal(t, fmt.Sprintf("input 3: %s", cause), err.Error())
+}
@@ -0,0 +1,82 @@
+package concurrent
+
+import (
+	"context"
+	"time"
+)
+
+// Metrics describes how an execution went, to help tuning numOfRoutines.
+type Metrics struct {
+	// TotalDuration is the wall-clock duration of the whole execution.
+	TotalDuration time.Duration
+	// MinItemDuration, MaxItemDuration, and AvgItemDuration are the shortest, the longest, and the average duration of
+	// a single process call.
+	MinItemDuration time.Duration
+	MaxItemDuration time.Duration
+	AvgItemDuration time.Duration
+	// ItemsPerWorker is the number of inputs processed by every worker, so an uneven distribution of the inputs shows
+	// up as uneven counts. Its length is the number of workers that were spawned.
+	ItemsPerWorker []int
+}
+
+// ExecuteWithMetrics works like Execute, and also reports the Metrics of the execution. Every worker keeps its own
+// figures, which are only merged once all inputs are processed, so the workers never contend with each other over them.
+func ExecuteWithMetrics[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) TypeOut) ([]TypeOut, Metrics) {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	type workerMetrics struct {
+		items int
+		total time.Duration
+		min   time.Duration
+		max   time.Duration
+	}
+
+	start := time.Now()
+	workers := make([]workerMetrics, workerCount(numOfRoutines, len(inputs)))
+	outputChannel := fanOut(context.Background(), numOfRoutines, inputs, func(worker, _ int, input TypeIn) TypeOut {
+		itemStart := time.Now()
+		output := process(input)
+		elapsed := time.Since(itemStart)
+
+		w := &workers[worker]
+		if w.items == 0 || elapsed < w.min {
+			w.min = elapsed
+		}
+		if elapsed > w.max {
+			w.max = elapsed
+		}
+		w.total += elapsed
+		w.items++
+
+		return output
+	})
+
+	outputs := make([]TypeOut, 0, len(inputs))
+	for o := range outputChannel {
+		outputs = append(outputs, o)
+	}
+
+	metrics := Metrics{ItemsPerWorker: make([]int, len(workers))}
+	var total time.Duration
+	for i, w := range workers {
+		metrics.ItemsPerWorker[i] = w.items
+		if w.items == 0 {
+			continue
+		}
+		if metrics.MinItemDuration == 0 || w.min < metrics.MinItemDuration {
+			metrics.MinItemDuration = w.min
+		}
+		if w.max > metrics.MaxItemDuration {
+			metrics.MaxItemDuration = w.max
+		}
+		total += w.total
+	}
+	if len(inputs) > 0 {
+		metrics.AvgItemDuration = total / time.Duration(len(inputs))
+	}
+	metrics.TotalDuration = time.Since(start)
+
+	return outputs, metrics
+}
@@ -0,0 +1,46 @@
+package concurrent_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteWithMetrics(t *testing.T) {
+	inputs := []time.Duration{
+		5 * time.Millisecond,
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+		5 * time.Millisecond,
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+	}
+
+	outputs, metrics := ExecuteWithMetrics(3, inputs, sleepFor)
+	assert.ElementsMatch(t, inputs, outputs)
+
+	assert.Len(t, metrics.ItemsPerWorker, 3)
+	processed := 0
+	for _, items := range metrics.ItemsPerWorker {
+		processed += items
+	}
+	assert.Equal(t, len(inputs), processed)
+
+	assert.GreaterOrEqual(t, metrics.MinItemDuration, 5*time.Millisecond)
+	assert.Less(t, metrics.MinItemDuration, 10*time.Millisecond)
+	assert.GreaterOrEqual(t, metrics.MaxItemDuration, 20*time.Millisecond)
+	assert.GreaterOrEqual(t, metrics.AvgItemDuration, metrics.MinItemDuration)
+	assert.LessOrEqual(t, metrics.AvgItemDuration, metrics.MaxItemDuration)
+	assert.GreaterOrEqual(t, metrics.TotalDuration, metrics.MaxItemDuration)
+}
+
+func TestExecuteWithMetricsEmptyInputs(t *testing.T) {
+	outputs, metrics := ExecuteWithMetrics(3, []int{}, func(in int) int {
+		return in
+	})
+	assert.Empty(t, outputs)
+	assert.Empty(t, metrics.ItemsPerWorker)
+	assert.Zero(t, metrics.AvgItemDuration)
+}
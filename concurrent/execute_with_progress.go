@@ -0,0 +1,42 @@
+package concurrent
+
+import (
+	"context"
+	"sync"
+)
+
+// ExecuteWithProgress works like Execute, but onProgress is called after every input is processed, with the number of
+// inputs processed so far and the total number of inputs. The calls to onProgress never overlap, and completed
+// increases by exactly one on every call, up to total on the last one.
+//
+// onProgress runs on the worker that just processed an input while holding a lock shared by all workers, so it should
+// return quickly, otherwise it slows down the whole execution. A nil onProgress makes ExecuteWithProgress work exactly
+// like Execute.
+func ExecuteWithProgress[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) TypeOut, onProgress func(completed, total int)) []TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+	if onProgress == nil {
+		return Execute(numOfRoutines, inputs, process)
+	}
+
+	var mu sync.Mutex
+	completed := 0
+	outputChannel := fanOut(context.Background(), numOfRoutines, inputs, func(_, _ int, input TypeIn) TypeOut {
+		output := process(input)
+
+		mu.Lock()
+		completed++
+		onProgress(completed, len(inputs))
+		mu.Unlock()
+
+		return output
+	})
+
+	outputs := make([]TypeOut, 0, len(inputs))
+	for o := range outputChannel {
+		outputs = append(outputs, o)
+	}
+
+	return outputs
+}
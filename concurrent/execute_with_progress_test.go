@@ -0,0 +1,35 @@
+package concurrent_test
+
+import (
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteWithProgress(t *testing.T) {
+	inputs := []testInput{
+		{value: "value1"},
+		{value: "value2"},
+		{value: "value3"},
+		{value: valueForErrorCase},
+		{value: "value4"},
+		{value: "value5"},
+	}
+
+	t.Run("onProgress is called for every processed input", func(t *testing.T) {
+		progress := []int{}
+		outputs := ExecuteWithProgress(3, inputs, testProcess, func(completed, total int) {
+			assert.Equal(t, len(inputs), total)
+			progress = append(progress, completed)
+		})
+
+		assert.Len(t, outputs, len(inputs))
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, progress)
+	})
+
+	t.Run("a nil onProgress is skipped", func(t *testing.T) {
+		outputs := ExecuteWithProgress(3, inputs, testProcess, nil)
+		assert.Len(t, outputs, len(inputs))
+	})
+}
@@ -0,0 +1,25 @@
+package concurrent
+
+import "time"
+
+// ExecuteWithRetry works like ExecuteWithError, but a failed process call is retried until it succeeds or it has been
+// attempted maxAttempts times, in which case the error of the last attempt is reported. The worker sleeps for backoff
+// before the first retry, and the sleep is doubled before every following retry. Only the worker of the failing input
+// sleeps, so the other workers keep processing their inputs meanwhile. A maxAttempts that is less than one is treated
+// as a single attempt.
+func ExecuteWithRetry[TypeIn any, TypeOut any](numOfRoutines, maxAttempts int, backoff time.Duration, inputs []TypeIn, process func(input TypeIn) (TypeOut, error)) ([]TypeOut, []error) {
+	if process == nil && len(inputs) > 0 {
+		return nil, []error{ErrNilProcess}
+	}
+
+	return ExecuteWithError(numOfRoutines, inputs, func(input TypeIn) (TypeOut, error) {
+		output, err := protect(process, input)
+		delay := backoff
+		for attempt := 1; err != nil && attempt < maxAttempts; attempt++ {
+			time.Sleep(delay)
+			delay *= 2
+			output, err = protect(process, input)
+		}
+		return output, err
+	})
+}
@@ -0,0 +1,82 @@
+package concurrent_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteWithRetry(t *testing.T) {
+	inputs := []string{"a", "b", "c"}
+
+	testCases := []struct {
+		name            string
+		maxAttempts     int
+		expectedOutputs []string
+		expectedErrs    int
+		expectedCalls   int
+	}{
+		{
+			name:            "succeeds on the third attempt",
+			maxAttempts:     3,
+			expectedOutputs: []string{"a", "b", "c"},
+			expectedErrs:    0,
+			expectedCalls:   3,
+		},
+		{
+			name:            "gives up after max attempts",
+			maxAttempts:     2,
+			expectedOutputs: []string{},
+			expectedErrs:    3,
+			expectedCalls:   2,
+		},
+		{
+			name:            "non-positive max attempts means a single attempt",
+			maxAttempts:     0,
+			expectedOutputs: []string{},
+			expectedErrs:    3,
+			expectedCalls:   1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			calls := map[string]int{}
+			outputs, errs := ExecuteWithRetry(3, tc.maxAttempts, time.Millisecond, inputs, func(in string) (string, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				calls[in]++
+				if calls[in] < 3 {
+					return "", fmt.Errorf("attempt %d failed", calls[in])
+				}
+				return in, nil
+			})
+
+			assert.ElementsMatch(t, tc.expectedOutputs, outputs)
+			assert.Len(t, errs, tc.expectedErrs)
+			for _, err := range errs {
+				var itemErr *ItemError
+				assert.True(t, errors.As(err, &itemErr))
+			}
+			for _, in := range inputs {
+				assert.Equal(t, tc.expectedCalls, calls[in])
+			}
+		})
+	}
+}
+
+func TestExecuteWithRetryBackoff(t *testing.T) {
+	start := time.Now()
+	_, errs := ExecuteWithRetry(1, 4, 10*time.Millisecond, []int{1}, func(in int) (int, error) {
+		return 0, errors.New("failed")
+	})
+	assert.Len(t, errs, 1)
+	// 10ms + 20ms + 40ms of backoff between the four attempts
+	assert.GreaterOrEqual(t, time.Since(start), 70*time.Millisecond)
+}
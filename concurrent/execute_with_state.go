@@ -0,0 +1,38 @@
+package concurrent
+
+import "context"
+
+// ExecuteWithState works like Execute, but every worker owns a state that is passed to every process call it makes,
+// which suits an expensive resource that should not be created for every input, such as a connection or a buffer.
+// Every worker creates its state by calling newState once before processing its first input, and passes it to
+// closeState right before exiting, unless closeState is nil. A state is only ever used by its own worker, so neither
+// the state nor process need any synchronization for it.
+func ExecuteWithState[TypeIn any, TypeOut any, S any](numOfRoutines int, inputs []TypeIn, newState func() S, process func(state S, input TypeIn) TypeOut, closeState func(state S)) []TypeOut {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	states := make([]S, workerCount(numOfRoutines, len(inputs)))
+	config := fanOutConfig{
+		onWorkerStart: func(worker int) bool {
+			states[worker] = newState()
+			return true
+		},
+	}
+	if closeState != nil {
+		config.onWorkerExit = func(worker int) {
+			closeState(states[worker])
+		}
+	}
+
+	outputChannel := fanOutWith(context.Background(), numOfRoutines, inputs, config, func(worker, _ int, input TypeIn) TypeOut {
+		return process(states[worker], input)
+	})
+
+	outputs := make([]TypeOut, 0, len(inputs))
+	for o := range outputChannel {
+		outputs = append(outputs, o)
+	}
+
+	return outputs
+}
@@ -0,0 +1,82 @@
+package concurrent_test
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+type testWorkerState struct {
+	id     int
+	items  int
+	closed bool
+}
+
+func TestExecuteWithState(t *testing.T) {
+	inputs := make([]int, 100)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	testCases := []struct {
+		name          string
+		numOfRoutines int
+		withClose     bool
+		expectedState int
+	}{
+		{
+			name:          "one state per worker",
+			numOfRoutines: 4,
+			withClose:     true,
+			expectedState: 4,
+		},
+		{
+			name:          "no more states than inputs",
+			numOfRoutines: 200,
+			withClose:     true,
+			expectedState: 100,
+		},
+		{
+			name:          "nil close state",
+			numOfRoutines: 4,
+			withClose:     false,
+			expectedState: 4,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			states := []*testWorkerState{}
+			newState := func() *testWorkerState {
+				mu.Lock()
+				defer mu.Unlock()
+				state := &testWorkerState{id: len(states)}
+				states = append(states, state)
+				return state
+			}
+			var closeState func(*testWorkerState)
+			if tc.withClose {
+				closeState = func(state *testWorkerState) {
+					state.closed = true
+				}
+			}
+
+			outputs := ExecuteWithState(tc.numOfRoutines, inputs, newState, func(state *testWorkerState, in int) int {
+				state.items++
+				return in
+			}, closeState)
+
+			assert.ElementsMatch(t, inputs, outputs)
+			assert.Len(t, states, tc.expectedState)
+			items := 0
+			for _, state := range states {
+				items += state.items
+				assert.Equal(t, tc.withClose, state.closed)
+			}
+			assert.Equal(t, len(inputs), items)
+		})
+	}
+}
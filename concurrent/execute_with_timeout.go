@@ -0,0 +1,46 @@
+package concurrent
+
+import "time"
+
+// ExecuteWithTimeout works like ExecuteWithError, but every process call is given at most the timeout duration to
+// finish. An input whose process call takes longer is reported as an *ItemError wrapping ErrItemTimeout, and the worker
+// moves on to the next input without waiting for it. A timeout that is zero or negative disables the timeout.
+//
+// Go has no way to stop a running goroutine, so a process call that timed out keeps running in the background until it
+// returns on its own, and its output is then discarded. A process that could hang forever therefore leaks a goroutine
+// on every timeout, and any side effect it has could still happen after ExecuteWithTimeout has returned. This holds
+// even when Sequential is set, in which case the abandoned call may overlap with the process calls of the next inputs.
+func ExecuteWithTimeout[TypeIn any, TypeOut any](numOfRoutines int, timeout time.Duration, inputs []TypeIn, process func(input TypeIn) (TypeOut, error)) ([]TypeOut, []error) {
+	if process == nil && len(inputs) > 0 {
+		return nil, []error{ErrNilProcess}
+	}
+
+	if timeout <= 0 {
+		return ExecuteWithError(numOfRoutines, inputs, process)
+	}
+
+	return ExecuteWithError(numOfRoutines, inputs, func(input TypeIn) (TypeOut, error) {
+		type result struct {
+			output TypeOut
+			err    error
+		}
+
+		// buffered, so the abandoned goroutine could still exit once process returns
+		resultChannel := make(chan result, 1)
+		go func() {
+			output, err := protect(process, input)
+			resultChannel <- result{output: output, err: err}
+		}()
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		select {
+		case r := <-resultChannel:
+			return r.output, r.err
+		case <-timer.C:
+			var zero TypeOut
+			return zero, ErrItemTimeout
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package concurrent_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteWithTimeout(t *testing.T) {
+	inputs := []time.Duration{
+		0,
+		time.Millisecond,
+		time.Second,
+		2 * time.Millisecond,
+		time.Second,
+	}
+	process := func(in time.Duration) (time.Duration, error) {
+		time.Sleep(in)
+		return in, nil
+	}
+
+	t.Run("slow inputs are reported as timed out", func(t *testing.T) {
+		start := time.Now()
+		outputs, errs := ExecuteWithTimeout(2, 50*time.Millisecond, inputs, process)
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+		assert.ElementsMatch(t, []time.Duration{0, time.Millisecond, 2 * time.Millisecond}, outputs)
+
+		indexes := []int{}
+		for _, err := range errs {
+			var itemErr *ItemError
+			assert.True(t, errors.Is(err, ErrItemTimeout))
+			if assert.True(t, errors.As(err, &itemErr)) {
+				indexes = append(indexes, itemErr.Index)
+			}
+		}
+		assert.ElementsMatch(t, []int{2, 4}, indexes)
+	})
+
+	t.Run("errors of process are still reported", func(t *testing.T) {
+		cause := errors.New("cause")
+		_, errs := ExecuteWithTimeout(2, time.Second, []int{1}, func(in int) (int, error) {
+			return 0, cause
+		})
+		if assert.Len(t, errs, 1) {
+			assert.True(t, errors.Is(errs[0], cause))
+		}
+	})
+}
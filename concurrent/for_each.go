@@ -0,0 +1,21 @@
+package concurrent
+
+import "context"
+
+// ForEach calls the process function for every input concurrently using numOfRoutines goroutines, and blocks until
+// all inputs are processed. It is meant for side-effecting processes that have nothing to return, so no output slice
+// is allocated at all.
+func ForEach[TypeIn any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn)) {
+	if process == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	done := fanOut(context.Background(), numOfRoutines, inputs, func(_, _ int, input TypeIn) struct{} {
+		process(input)
+		return struct{}{}
+	})
+
+	// wait for all inputs to be processed
+	for range done {
+	}
+}
@@ -0,0 +1,44 @@
+package concurrent_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEach(t *testing.T) {
+	inputs := make([]int64, 100)
+	expectedSum := int64(0)
+	for i := range inputs {
+		inputs[i] = int64(i)
+		expectedSum += int64(i)
+	}
+
+	testCases := []struct {
+		name          string
+		numOfRoutines int
+	}{
+		{
+			name:          "single routine",
+			numOfRoutines: 1,
+		},
+		{
+			name:          "multiple routines",
+			numOfRoutines: 8,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var sum, calls int64
+			ForEach(tc.numOfRoutines, inputs, func(in int64) {
+				atomic.AddInt64(&sum, in)
+				atomic.AddInt64(&calls, 1)
+			})
+			assert.Equal(t, expectedSum, atomic.LoadInt64(&sum))
+			assert.Equal(t, int64(len(inputs)), atomic.LoadInt64(&calls))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package concurrent
+
+import "time"
+
+// Future is the handle of a single function running in the background, as started by Async, whose result could be
+// waited for with Get or GetWithTimeout from any number of goroutines.
+type Future[T any] struct {
+	done     chan struct{}
+	value    T
+	panicErr *PanicError
+}
+
+// Async calls fn in a goroutine of its own and returns immediately with a Future of its result, for the one-off tasks
+// that do not fit the slices of inputs of Execute. fn is called exactly once, however many times the result is waited
+// for. A panic inside fn is recovered, and raised again by every Get or GetWithTimeout call, as a *PanicError holding
+// the stack trace of the goroutine of fn, like the PanicPropagate policy of ExecuteWithOptions does.
+func Async[T any](fn func() T) *Future[T] {
+	if fn == nil {
+		panic(ErrNilProcess)
+	}
+
+	f := &Future[T]{done: make(chan struct{})}
+	go func() {
+		defer close(f.done)
+		value, err := protect(func(struct{}) (T, error) {
+			return fn(), nil
+		}, struct{}{})
+		if err != nil {
+			f.panicErr = err.(*PanicError)
+			return
+		}
+		f.value = value
+	}()
+
+	return f
+}
+
+// Get blocks until fn has returned, and returns its result.
+func (f *Future[T]) Get() T {
+	<-f.done
+	return f.result()
+}
+
+// GetWithTimeout works like Get, but it gives up after waiting for d, in which case it returns the zero value and
+// false, while fn keeps running in the background. A d that is zero or negative does not wait at all.
+func (f *Future[T]) GetWithTimeout(d time.Duration) (T, bool) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-f.done:
+		return f.result(), true
+	case <-timer.C:
+		// fn might have returned right as the timer fired
+		select {
+		case <-f.done:
+			return f.result(), true
+		default:
+			var zero T
+			return zero, false
+		}
+	}
+}
+
+// result returns the result of fn once it has returned, or panics again with its panic.
+func (f *Future[T]) result() T {
+	if f.panicErr != nil {
+		panic(f.panicErr)
+	}
+	return f.value
+}
@@ -0,0 +1,69 @@
+package concurrent_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuture(t *testing.T) {
+	t.Run("concurrent Get calls share a single call of fn", func(t *testing.T) {
+		var calls int64
+		f := Async(func() int {
+			atomic.AddInt64(&calls, 1)
+			time.Sleep(10 * time.Millisecond)
+			return 42
+		})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.Equal(t, 42, f.Get())
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 42, f.Get())
+		assert.Equal(t, int64(1), atomic.LoadInt64(&calls))
+	})
+
+	t.Run("GetWithTimeout gives up on a slow fn", func(t *testing.T) {
+		release := make(chan struct{})
+		f := Async(func() string {
+			<-release
+			return "done"
+		})
+
+		value, ok := f.GetWithTimeout(10 * time.Millisecond)
+		assert.False(t, ok)
+		assert.Empty(t, value)
+
+		close(release)
+		value, ok = f.GetWithTimeout(time.Second)
+		assert.True(t, ok)
+		assert.Equal(t, "done", value)
+	})
+
+	t.Run("a panic is raised again by Get", func(t *testing.T) {
+		f := Async(func() int {
+			panic("unexpected")
+		})
+
+		for i := 0; i < 2; i++ {
+			func() {
+				defer func() {
+					panicErr, ok := recover().(*PanicError)
+					if assert.True(t, ok) {
+						assert.Equal(t, "unexpected", panicErr.Value)
+					}
+				}()
+				f.Get()
+			}()
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package concurrent
+
+import (
+	"context"
+	"sync"
+)
+
+// Group processes inputs that are handed to it one at a time with Go, rather than as a slice known up front like
+// Execute, and stops at the first error like ExecuteUntilError, in the spirit of golang.org/x/sync/errgroup, but with
+// a single typed process function whose outputs are gathered. A Group must be created with NewGroup.
+//
+// The context passed to process is canceled as soon as a process call fails, or once Wait returns, so that the other
+// process calls could abort early. Go could be called from multiple goroutines, but every call to Go must happen
+// before Wait is called.
+type Group[TypeIn any, TypeOut any] struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	process func(ctx context.Context, input TypeIn) (TypeOut, error)
+	slots   *Semaphore
+	wg      sync.WaitGroup
+
+	// mu guards next, which is the index of the next input handed to Go, and the outputs and firstErr gathered so far.
+	mu       sync.Mutex
+	next     int
+	outputs  []TypeOut
+	firstErr error
+}
+
+// NewGroup returns a Group that processes the inputs handed to it with at most numOfRoutines process calls running at
+// the same time. The context passed to process is derived from ctx.
+func NewGroup[TypeIn any, TypeOut any](ctx context.Context, numOfRoutines int, process func(ctx context.Context, input TypeIn) (TypeOut, error)) *Group[TypeIn, TypeOut] {
+	if process == nil {
+		panic(ErrNilProcess)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group[TypeIn, TypeOut]{
+		ctx:     ctx,
+		cancel:  cancel,
+		process: process,
+		slots:   NewSemaphore(routinesOrDefault(numOfRoutines)),
+		outputs: []TypeOut{},
+	}
+}
+
+// Go processes the input in a goroutine of its own, blocking until fewer than numOfRoutines process calls are still
+// running. Once a process call has failed, or the context of the Group is done, the input is dropped without being
+// processed.
+func (g *Group[TypeIn, TypeOut]) Go(input TypeIn) {
+	g.mu.Lock()
+	index := g.next
+	g.next++
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+	g.slots.Acquire()
+	if g.ctx.Err() != nil {
+		g.slots.Release()
+		g.wg.Done()
+		return
+	}
+
+	go func() {
+		defer g.wg.Done()
+		defer g.slots.Release()
+
+		output, err := protect(func(input TypeIn) (TypeOut, error) {
+			return g.process(g.ctx, input)
+		}, input)
+
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		if err != nil {
+			if g.firstErr == nil {
+				g.firstErr = &ItemError{Index: index, Err: err}
+				g.cancel()
+			}
+			return
+		}
+		g.outputs = append(g.outputs, output)
+	}()
+}
+
+// Wait blocks until every input handed to Go is processed, then returns the outputs of the successful process calls,
+// in no particular order, along with the first error returned by process as an *ItemError, whose Index is the
+// position of the failed input among the calls to Go. A panic inside process is recovered and reported as a
+// *PanicError. The error is nil when every process call succeeded.
+func (g *Group[TypeIn, TypeOut]) Wait() ([]TypeOut, error) {
+	g.wg.Wait()
+	g.cancel()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.outputs, g.firstErr
+}
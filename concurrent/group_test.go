@@ -0,0 +1,73 @@
+package concurrent_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup(t *testing.T) {
+	t.Run("every output is returned when nothing fails", func(t *testing.T) {
+		g := NewGroup(context.Background(), 3, func(_ context.Context, in int) (int, error) {
+			return in * 2, nil
+		})
+		for i := 0; i < 10; i++ {
+			g.Go(i)
+		}
+
+		outputs, err := g.Wait()
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}, outputs)
+	})
+
+	t.Run("the first error cancels the other process calls", func(t *testing.T) {
+		errFail := errors.New("fail")
+		var canceled int64
+		var counter peakCounter
+		g := NewGroup(context.Background(), 4, func(ctx context.Context, in int) (int, error) {
+			counter.enter()
+			defer counter.exit()
+			if in == 3 {
+				return 0, errFail
+			}
+			select {
+			case <-ctx.Done():
+				atomic.AddInt64(&canceled, 1)
+				return 0, ctx.Err()
+			case <-time.After(time.Second):
+				return in, nil
+			}
+		})
+		start := time.Now()
+		for i := 0; i < 20; i++ {
+			g.Go(i)
+		}
+
+		outputs, err := g.Wait()
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Empty(t, outputs)
+		assert.ErrorIs(t, err, errFail)
+		var itemErr *ItemError
+		if assert.True(t, errors.As(err, &itemErr)) {
+			assert.Equal(t, 3, itemErr.Index)
+		}
+		assert.Equal(t, int64(3), atomic.LoadInt64(&canceled))
+		assert.LessOrEqual(t, counter.max(), int64(4))
+	})
+
+	t.Run("a panic is reported as an error", func(t *testing.T) {
+		g := NewGroup(context.Background(), 2, func(_ context.Context, in int) (int, error) {
+			panic("unexpected input")
+		})
+		g.Go(1)
+
+		_, err := g.Wait()
+		var panicErr *PanicError
+		assert.True(t, errors.As(err, &panicErr))
+	})
+}
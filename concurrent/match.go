@@ -0,0 +1,42 @@
+package concurrent
+
+import "context"
+
+// AnyMatch reports whether the predicate holds for at least one of the inputs, calling it concurrently. As soon as
+// one input matches, no more inputs are handed to the workers, and true is returned once the inputs still being
+// checked at that moment are done. An empty inputs slice reports false.
+func AnyMatch[TypeIn any](numOfRoutines int, inputs []TypeIn, predicate func(input TypeIn) bool) bool {
+	if predicate == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	outputChannel := fanOut(ctx, numOfRoutines, inputs, func(_, _ int, input TypeIn) bool {
+		return predicate(input)
+	})
+
+	matched := false
+	for ok := range outputChannel {
+		if ok && !matched {
+			matched = true
+			cancel()
+		}
+	}
+
+	return matched
+}
+
+// AllMatch reports whether the predicate holds for every one of the inputs, calling it concurrently. As soon as one
+// input does not match, no more inputs are handed to the workers, and false is returned once the inputs still being
+// checked at that moment are done. An empty inputs slice reports true.
+func AllMatch[TypeIn any](numOfRoutines int, inputs []TypeIn, predicate func(input TypeIn) bool) bool {
+	if predicate == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	return !AnyMatch(numOfRoutines, inputs, func(input TypeIn) bool {
+		return !predicate(input)
+	})
+}
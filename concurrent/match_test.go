@@ -0,0 +1,131 @@
+package concurrent_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnyMatch(t *testing.T) {
+	inputs := make([]int, 10000)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	testCases := []struct {
+		name          string
+		inputs        []int
+		predicate     func(int) bool
+		expected      bool
+		expectedCalls func(calls int64) bool
+	}{
+		{
+			name:   "a match near the start stops the distribution",
+			inputs: inputs,
+			predicate: func(in int) bool {
+				return in == 5
+			},
+			expected: true,
+			expectedCalls: func(calls int64) bool {
+				return calls < int64(len(inputs))/2
+			},
+		},
+		{
+			name:   "no match checks every input",
+			inputs: inputs,
+			predicate: func(in int) bool {
+				return in < 0
+			},
+			expected: false,
+			expectedCalls: func(calls int64) bool {
+				return calls == int64(len(inputs))
+			},
+		},
+		{
+			name:   "empty inputs do not match",
+			inputs: []int{},
+			predicate: func(in int) bool {
+				return true
+			},
+			expected: false,
+			expectedCalls: func(calls int64) bool {
+				return calls == 0
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int64
+			matched := AnyMatch(4, tc.inputs, func(in int) bool {
+				atomic.AddInt64(&calls, 1)
+				return tc.predicate(in)
+			})
+			assert.Equal(t, tc.expected, matched)
+			assert.True(t, tc.expectedCalls(atomic.LoadInt64(&calls)))
+		})
+	}
+}
+
+func TestAllMatch(t *testing.T) {
+	inputs := make([]int, 10000)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	testCases := []struct {
+		name          string
+		inputs        []int
+		predicate     func(int) bool
+		expected      bool
+		expectedCalls func(calls int64) bool
+	}{
+		{
+			name:   "a failure near the start stops the distribution",
+			inputs: inputs,
+			predicate: func(in int) bool {
+				return in != 5
+			},
+			expected: false,
+			expectedCalls: func(calls int64) bool {
+				return calls < int64(len(inputs))/2
+			},
+		},
+		{
+			name:   "every input matching checks every input",
+			inputs: inputs,
+			predicate: func(in int) bool {
+				return in >= 0
+			},
+			expected: true,
+			expectedCalls: func(calls int64) bool {
+				return calls == int64(len(inputs))
+			},
+		},
+		{
+			name:   "empty inputs match",
+			inputs: []int{},
+			predicate: func(in int) bool {
+				return false
+			},
+			expected: true,
+			expectedCalls: func(calls int64) bool {
+				return calls == 0
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int64
+			matched := AllMatch(4, tc.inputs, func(in int) bool {
+				atomic.AddInt64(&calls, 1)
+				return tc.predicate(in)
+			})
+			assert.Equal(t, tc.expected, matched)
+			assert.True(t, tc.expectedCalls(atomic.LoadInt64(&calls)))
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package concurrent
+
+import "sync"
+
+// Merge forwards every value received from any of the channels to the returned channel, which is closed once all of
+// the channels are closed. The values of a single channel keep their order, but the values of different channels are
+// interleaved in no particular order.
+func Merge[T any](channels ...<-chan T) <-chan T {
+	outputChannel := make(chan T)
+
+	var wg sync.WaitGroup
+	wg.Add(len(channels))
+	for _, channel := range channels {
+		go func(channel <-chan T) {
+			defer wg.Done()
+			for v := range channel {
+				outputChannel <- v
+			}
+		}(channel)
+	}
+
+	go func() {
+		wg.Wait()
+		close(outputChannel)
+	}()
+
+	return outputChannel
+}
@@ -0,0 +1,23 @@
+package concurrent_test
+
+import (
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	t.Run("every value of every channel appears once", func(t *testing.T) {
+		merged := Merge(
+			generate(1, 2, 3),
+			generate(4),
+			generate(5, 6, 7, 8, 9),
+		)
+		assert.ElementsMatch(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, drain(merged))
+	})
+
+	t.Run("no channels closes the output", func(t *testing.T) {
+		assert.Empty(t, drain(Merge[int]()))
+	})
+}
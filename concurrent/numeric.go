@@ -0,0 +1,69 @@
+package concurrent
+
+// Number is the constraint of the numeric types that ConcurrentSum, ConcurrentMax and ConcurrentMin could aggregate.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// ConcurrentSum returns the sum of the values of the inputs, calling value concurrently like ExecuteReduce does, with
+// the same per-worker accumulators. The sum of an empty inputs slice is zero.
+func ConcurrentSum[TypeIn any, N Number](numOfRoutines int, inputs []TypeIn, value func(input TypeIn) N) N {
+	return ExecuteReduce(numOfRoutines, inputs, 0, value, func(a, b N) N {
+		return a + b
+	})
+}
+
+// ConcurrentCount returns the number of inputs for which the predicate holds, calling it concurrently.
+func ConcurrentCount[TypeIn any](numOfRoutines int, inputs []TypeIn, predicate func(input TypeIn) bool) int {
+	if predicate == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	return ConcurrentSum(numOfRoutines, inputs, func(input TypeIn) int {
+		if predicate(input) {
+			return 1
+		}
+		return 0
+	})
+}
+
+// ConcurrentMax returns the largest of the values of the inputs, calling value concurrently. The returned bool is
+// false, along with a zero value, when inputs is empty.
+func ConcurrentMax[TypeIn any, N Number](numOfRoutines int, inputs []TypeIn, value func(input TypeIn) N) (N, bool) {
+	return extremum(numOfRoutines, inputs, value, func(a, b N) bool {
+		return a > b
+	})
+}
+
+// ConcurrentMin returns the smallest of the values of the inputs, calling value concurrently. The returned bool is
+// false, along with a zero value, when inputs is empty.
+func ConcurrentMin[TypeIn any, N Number](numOfRoutines int, inputs []TypeIn, value func(input TypeIn) N) (N, bool) {
+	return extremum(numOfRoutines, inputs, value, func(a, b N) bool {
+		return a < b
+	})
+}
+
+// extremum returns the value of the inputs that is preferred over all the others according to better.
+func extremum[TypeIn any, N Number](numOfRoutines int, inputs []TypeIn, value func(input TypeIn) N, better func(a, b N) bool) (N, bool) {
+	if value == nil && len(inputs) > 0 {
+		panic(ErrNilProcess)
+	}
+
+	// the ok flag makes the zero candidate an identity element, whatever the sign of the values
+	type candidate struct {
+		value N
+		ok    bool
+	}
+
+	c := ExecuteReduce(numOfRoutines, inputs, candidate{}, func(input TypeIn) candidate {
+		return candidate{value: value(input), ok: true}
+	}, func(a, b candidate) candidate {
+		if !a.ok || (b.ok && better(b.value, a.value)) {
+			return b
+		}
+		return a
+	})
+	return c.value, c.ok
+}
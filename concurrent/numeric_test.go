@@ -0,0 +1,75 @@
+package concurrent_test
+
+import (
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentSum(t *testing.T) {
+	inputs := make([]int, 1000)
+	for i := range inputs {
+		inputs[i] = i + 1
+	}
+
+	assert.Equal(t, 500500, ConcurrentSum(4, inputs, func(in int) int { return in }))
+	assert.InDelta(t, 250.25, ConcurrentSum(4, inputs, func(in int) float64 { return float64(in) / 2000 }), 1e-9)
+	assert.Zero(t, ConcurrentSum(4, []int{}, func(in int) int { return in }))
+}
+
+func TestConcurrentCount(t *testing.T) {
+	inputs := make([]int, 1000)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	assert.Equal(t, 500, ConcurrentCount(4, inputs, func(in int) bool { return in%2 == 0 }))
+	assert.Zero(t, ConcurrentCount(4, []int{}, func(in int) bool { return true }))
+}
+
+func TestConcurrentMaxMin(t *testing.T) {
+	testCases := []struct {
+		name        string
+		inputs      []int
+		expectedMax int
+		expectedMin int
+		expectedOk  bool
+	}{
+		{
+			name:        "mixed signs",
+			inputs:      []int{3, -7, 12, 0, -1, 5},
+			expectedMax: 12,
+			expectedMin: -7,
+			expectedOk:  true,
+		},
+		{
+			name:        "only negative values",
+			inputs:      []int{-3, -7, -2},
+			expectedMax: -2,
+			expectedMin: -7,
+			expectedOk:  true,
+		},
+		{
+			name:        "empty inputs",
+			inputs:      []int{},
+			expectedMax: 0,
+			expectedMin: 0,
+			expectedOk:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			identity := func(in int) int { return in }
+
+			maxValue, ok := ConcurrentMax(3, tc.inputs, identity)
+			assert.Equal(t, tc.expectedOk, ok)
+			assert.Equal(t, tc.expectedMax, maxValue)
+
+			minValue, ok := ConcurrentMin(3, tc.inputs, identity)
+			assert.Equal(t, tc.expectedOk, ok)
+			assert.Equal(t, tc.expectedMin, minValue)
+		})
+	}
+}
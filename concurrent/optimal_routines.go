@@ -0,0 +1,37 @@
+package concurrent
+
+import "runtime"
+
+// WorkloadType tells what bounds the duration of the process calls, for OptimalRoutines.
+type WorkloadType int
+
+const (
+	// CPUBound is a workload whose process calls keep the CPU busy, e.g. hashing or encoding, which gains nothing from
+	// more goroutines than CPUs.
+	CPUBound WorkloadType = iota
+	// IOBound is a workload whose process calls mostly wait, e.g. on the network or the disk, which keeps gaining from
+	// more goroutines than CPUs, since most of them are idle at any time.
+	IOBound
+)
+
+// CPUBoundMultiplier and IOBoundMultiplier are the number of goroutines per CPU that OptimalRoutines suggests for the
+// CPUBound and the IOBound workloads. Like DefaultRoutines, they could be overridden once at startup to fit what the
+// workloads of an application actually gain from.
+var (
+	CPUBoundMultiplier = 1
+	IOBoundMultiplier  = 8
+)
+
+// OptimalRoutines returns a suggested numOfRoutines for a workload of the given type, which is runtime.NumCPU() times
+// the multiplier of that type, and at least one. It is only a starting point codifying the usual heuristic, so the
+// number that works best should still be measured. An unknown workloadType is treated as CPUBound.
+func OptimalRoutines(workloadType WorkloadType) int {
+	multiplier := CPUBoundMultiplier
+	if workloadType == IOBound {
+		multiplier = IOBoundMultiplier
+	}
+	if routines := runtime.NumCPU() * multiplier; routines > 0 {
+		return routines
+	}
+	return 1
+}
@@ -0,0 +1,32 @@
+package concurrent_test
+
+import (
+	"runtime"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptimalRoutines(t *testing.T) {
+	t.Run("a CPU-bound workload gets one goroutine per CPU", func(t *testing.T) {
+		assert.Equal(t, runtime.NumCPU(), OptimalRoutines(CPUBound))
+	})
+
+	t.Run("an IO-bound workload gets more goroutines than CPUs", func(t *testing.T) {
+		assert.Greater(t, OptimalRoutines(IOBound), OptimalRoutines(CPUBound))
+	})
+
+	t.Run("the multipliers could be overridden", func(t *testing.T) {
+		ioBoundMultiplier := IOBoundMultiplier
+		defer func() {
+			IOBoundMultiplier = ioBoundMultiplier
+		}()
+
+		IOBoundMultiplier = 3
+		assert.Equal(t, 3*runtime.NumCPU(), OptimalRoutines(IOBound))
+
+		IOBoundMultiplier = 0
+		assert.Equal(t, 1, OptimalRoutines(IOBound))
+	})
+}
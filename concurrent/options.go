@@ -0,0 +1,221 @@
+package concurrent
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// PanicPolicy tells what happens when a process call panics.
+type PanicPolicy int
+
+const (
+	// PanicRecoverAsError recovers the panic and reports it among the returned errors, as an *ItemError wrapping a
+	// *PanicError that holds the panic value and the stack trace of the panic. This is the default policy.
+	PanicRecoverAsError PanicPolicy = iota
+	// PanicPropagate stops handing inputs to the workers as soon as a process call panics, and once the workers are
+	// done, panics again on the calling goroutine with the *PanicError of the first panic, stack trace included.
+	PanicPropagate
+	// PanicRecover recovers the panic and silently drops the input, which contributes neither an output nor an error.
+	PanicRecover
+)
+
+// DistributionStrategy tells how the inputs are distributed to the workers.
+type DistributionStrategy int
+
+const (
+	// SharedQueue lets every worker pick the next input from a queue shared by all workers as soon as it is free, so
+	// that a slow input only holds back its own worker. This is the default strategy.
+	SharedQueue DistributionStrategy = iota
+	// RoundRobin assigns the input at index i to the worker i modulo the number of workers up front, which avoids the
+	// contention on the shared queue for many cheap inputs, but leaves workers idle when the processing times vary.
+	RoundRobin
+	// Weighted assigns the inputs to the workers up front by their weight, like ExecuteWeighted does, so that every
+	// worker gets roughly the same total weight. It requires Options.Weight, without which SharedQueue is used.
+	Weighted
+)
+
+// Options tunes the execution of ExecuteWithOptions. The zero value of every field keeps the default behavior, so the
+// zero Options makes ExecuteWithOptions work exactly like ExecuteWithError.
+type Options[TypeIn any] struct {
+	// BufferSize is the capacity of the channel the workers send their outputs to, which lets a worker hand over its
+	// output and move on to the next input without waiting for it to be gathered. It defaults to the number of workers
+	// when zero, and a negative BufferSize makes the channel unbuffered, so every worker waits until its output is
+	// gathered.
+	BufferSize int
+	// MaxInFlight is the maximum number of process calls that could run at the same time, independently of the number
+	// of workers, which is useful when there are more workers than operations that are allowed to run at once. It is
+	// not limited, i.e. only bounded by the number of workers, when zero or negative.
+	MaxInFlight int
+	// OnError, when not nil, is called with the index, the input, and the error of every input that fails, right when
+	// it fails rather than at the end of the execution. It is called by the worker that processed the input, so it
+	// could be called concurrently by multiple workers, and it must do its own synchronization when it needs any.
+	OnError func(index int, input TypeIn, err error)
+	// PanicPolicy tells what happens when a process call panics, see the PanicPolicy constants.
+	PanicPolicy PanicPolicy
+	// MinInterval is the minimum duration between handing an input to a worker and handing the next one to any worker,
+	// for the APIs that require a strict gap between calls. Only the start of the process calls is spaced out, so with
+	// multiple workers, slow process calls could still overlap. It is not enforced when zero or negative.
+	MinInterval time.Duration
+	// Distribution tells how the inputs are distributed to the workers, see the DistributionStrategy constants.
+	Distribution DistributionStrategy
+	// Weight returns the weight of an input for the Weighted distribution, and is ignored by the other ones.
+	Weight func(input TypeIn) int
+	// Skip, when not nil, is called for every input right before it is processed, and an input for which it returns
+	// true is dropped without calling process, contributing neither an output nor an error. Like OnError, it is called
+	// by the workers, so it could be called concurrently, and it should be cheap compared to process.
+	Skip func(input TypeIn) bool
+	// Recorder, when not nil, observes the duration of every process call, failed and panicking ones included.
+	Recorder Recorder
+	// WatchdogTimeout, when positive, is the duration after which a stalled execution, i.e. one that has not gathered
+	// any output for that long, logs a warning through the log package with the number of inputs still pending and the
+	// number of workers stuck in a process call, and again after every WatchdogTimeout that the stall lasts. It only
+	// surfaces the stall, e.g. a process that deadlocks on an unbuffered channel, without aborting the execution.
+	WatchdogTimeout time.Duration
+	// Label, when not empty, tags every worker goroutine with the pprof label "concurrent" set to Label, so that the
+	// workers of different executions could be told apart in the profiles and the goroutine dumps, e.g. with the
+	// -tagfocus flag of pprof. The process calls inherit the label, and no label is set at all when Label is empty.
+	Label string
+}
+
+// labelKey is the key of the pprof label set by Options.Label.
+const labelKey = "concurrent"
+
+// ExecuteWithOptions works like ExecuteWithError, with its execution tuned by opts.
+func ExecuteWithOptions[TypeIn any, TypeOut any](numOfRoutines int, inputs []TypeIn, process func(input TypeIn) (TypeOut, error), opts Options[TypeIn]) ([]TypeOut, []error) {
+	if process == nil && len(inputs) > 0 {
+		return nil, []error{ErrNilProcess}
+	}
+
+	type result struct {
+		output  TypeOut
+		err     error
+		dropped bool
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var panicOnce sync.Once
+	var firstPanic *PanicError
+
+	var inFlight *Semaphore
+	if opts.MaxInFlight > 0 {
+		inFlight = NewSemaphore(opts.MaxInFlight)
+	}
+
+	config := fanOutConfig{
+		bufferSize:  opts.BufferSize,
+		minInterval: opts.MinInterval,
+		label:       opts.Label,
+	}
+	switch {
+	case opts.Distribution == RoundRobin:
+		config.assign = func(numOfWorkers int) [][]int {
+			return assignRoundRobin(numOfWorkers, len(inputs))
+		}
+	case opts.Distribution == Weighted && opts.Weight != nil:
+		config.assign = func(numOfWorkers int) [][]int {
+			return assignByWeight(numOfWorkers, inputs, opts.Weight)
+		}
+	}
+	var processing, gathered int64
+	progress := make(chan struct{}, 1)
+	if opts.WatchdogTimeout > 0 {
+		stop := watch(opts.WatchdogTimeout, progress, func() {
+			log.Printf("concurrent: no output gathered for %s, %d inputs pending, %d workers stuck in process",
+				opts.WatchdogTimeout, len(inputs)-int(atomic.LoadInt64(&gathered)), atomic.LoadInt64(&processing))
+		})
+		defer stop()
+	}
+
+	outputChannel := fanOutWith(ctx, numOfRoutines, inputs, config, func(_, index int, input TypeIn) result {
+		if opts.Skip != nil && opts.Skip(input) {
+			return result{dropped: true}
+		}
+		if inFlight != nil {
+			inFlight.Acquire()
+			defer inFlight.Release()
+		}
+
+		start := time.Now()
+		atomic.AddInt64(&processing, 1)
+		output, err := protect(process, input)
+		atomic.AddInt64(&processing, -1)
+		if opts.Recorder != nil {
+			opts.Recorder.Observe(time.Since(start))
+		}
+		if panicErr, ok := err.(*PanicError); ok && opts.PanicPolicy != PanicRecoverAsError {
+			if opts.PanicPolicy == PanicPropagate {
+				panicOnce.Do(func() {
+					firstPanic = panicErr
+					cancel()
+				})
+			}
+			return result{dropped: true}
+		}
+		if err != nil {
+			if opts.OnError != nil {
+				opts.OnError(index, input, err)
+			}
+			return result{err: &ItemError{Index: index, Err: err}}
+		}
+		return result{output: output}
+	})
+
+	outputs := make([]TypeOut, 0, len(inputs))
+	errs := []error{}
+	for r := range outputChannel {
+		atomic.AddInt64(&gathered, 1)
+		// never blocks, the watchdog only needs to know that some progress was made since it last checked
+		select {
+		case progress <- struct{}{}:
+		default:
+		}
+		if r.dropped {
+			continue
+		}
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		outputs = append(outputs, r.output)
+	}
+
+	if firstPanic != nil {
+		panic(firstPanic)
+	}
+
+	return outputs, errs
+}
+
+// watch runs the watchdog of ExecuteWithOptions, which calls warn every time timeout elapses without anything received
+// from progress. It returns a function that stops the watchdog and waits for it to exit.
+func watch(timeout time.Duration, progress <-chan struct{}, warn func()) (stop func()) {
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		for {
+			select {
+			case <-progress:
+				if !timer.Stop() {
+					<-timer.C
+				}
+			case <-timer.C:
+				warn()
+			case <-done:
+				return
+			}
+			timer.Reset(timeout)
+		}
+	}()
+	return func() {
+		close(done)
+		<-exited
+	}
+}
@@ -0,0 +1,463 @@
+package concurrent_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/pprof"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteWithOptions(t *testing.T) {
+	inputs := make([]int, 100)
+	for i := range inputs {
+		inputs[i] = i
+	}
+	increment := func(in int) (int, error) {
+		return in + 1, nil
+	}
+	expectedOutputs := make([]int, len(inputs))
+	for i := range inputs {
+		expectedOutputs[i] = i + 1
+	}
+
+	testCases := []struct {
+		name string
+		opts Options[int]
+	}{
+		{
+			name: "default options",
+			opts: Options[int]{},
+		},
+		{
+			name: "unbuffered outputs",
+			opts: Options[int]{BufferSize: -1},
+		},
+		{
+			name: "buffered outputs",
+			opts: Options[int]{BufferSize: 16},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			outputs, errs := ExecuteWithOptions(4, inputs, increment, tc.opts)
+			assert.Empty(t, errs)
+			assert.ElementsMatch(t, expectedOutputs, outputs)
+		})
+	}
+}
+
+func TestExecuteWithOptionsMaxInFlight(t *testing.T) {
+	inputs := make([]int, 50)
+
+	testCases := []struct {
+		name         string
+		maxInFlight  int
+		expectedPeak int64
+	}{
+		{
+			name:         "in-flight calls are limited",
+			maxInFlight:  3,
+			expectedPeak: 3,
+		},
+		{
+			name:         "zero means limited by the workers only",
+			maxInFlight:  0,
+			expectedPeak: 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var counter peakCounter
+			outputs, errs := ExecuteWithOptions(10, inputs, func(in int) (int, error) {
+				counter.enter()
+				defer counter.exit()
+				time.Sleep(5 * time.Millisecond)
+				return in, nil
+			}, Options[int]{MaxInFlight: tc.maxInFlight})
+
+			assert.Empty(t, errs)
+			assert.Len(t, outputs, len(inputs))
+			assert.LessOrEqual(t, counter.max(), tc.expectedPeak)
+		})
+	}
+}
+
+func TestExecuteWithOptionsOnError(t *testing.T) {
+	inputs := []testInput{
+		{value: "value1"},
+		{value: valueForErrorCase},
+		{value: "value2"},
+		{value: valueForErrorCase},
+		{value: "value3"},
+	}
+
+	var mu sync.Mutex
+	failedIndexes := []int{}
+	outputs, errs := ExecuteWithOptions(3, inputs, testProcessWithError, Options[testInput]{
+		OnError: func(index int, input testInput, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, valueForErrorCase, input.value)
+			assert.Error(t, err)
+			failedIndexes = append(failedIndexes, index)
+		},
+	})
+
+	assert.Len(t, outputs, 3)
+	assert.Len(t, errs, 2)
+	assert.ElementsMatch(t, []int{1, 3}, failedIndexes)
+}
+
+func TestExecuteWithOptionsPanicPolicy(t *testing.T) {
+	inputs := make([]int, 30)
+	for i := range inputs {
+		inputs[i] = i
+	}
+	process := func(in int) (int, error) {
+		if in%10 == 5 {
+			panic(fmt.Sprintf("cannot process %d", in))
+		}
+		return in, nil
+	}
+
+	t.Run("recover as error", func(t *testing.T) {
+		outputs, errs := ExecuteWithOptions(4, inputs, process, Options[int]{PanicPolicy: PanicRecoverAsError})
+		assert.Len(t, outputs, 27)
+		assert.Len(t, errs, 3)
+		for _, err := range errs {
+			var itemErr *ItemError
+			var panicErr *PanicError
+			if assert.True(t, errors.As(err, &itemErr)) && assert.True(t, errors.As(err, &panicErr)) {
+				assert.Equal(t, fmt.Sprintf("cannot process %d", itemErr.Index), panicErr.Value)
+				assert.NotEmpty(t, panicErr.Stack)
+			}
+		}
+	})
+
+	t.Run("recover", func(t *testing.T) {
+		outputs, errs := ExecuteWithOptions(4, inputs, process, Options[int]{PanicPolicy: PanicRecover})
+		assert.Len(t, outputs, 27)
+		assert.Empty(t, errs)
+		for _, o := range outputs {
+			assert.NotEqual(t, 5, o%10)
+		}
+	})
+
+	t.Run("propagate", func(t *testing.T) {
+		defer func() {
+			r := recover()
+			panicErr, ok := r.(*PanicError)
+			if assert.True(t, ok) {
+				assert.Contains(t, panicErr.Value, "cannot process")
+				assert.NotEmpty(t, panicErr.Stack)
+			}
+		}()
+		ExecuteWithOptions(4, inputs, process, Options[int]{PanicPolicy: PanicPropagate})
+		assert.Fail(t, "ExecuteWithOptions should have panicked")
+	})
+}
+
+func TestExecuteWithOptionsMinInterval(t *testing.T) {
+	const minInterval = 20 * time.Millisecond
+	inputs := make([]int, 6)
+
+	var mu sync.Mutex
+	dispatchedAt := []time.Time{}
+	outputs, errs := ExecuteWithOptions(3, inputs, func(in int) (int, error) {
+		mu.Lock()
+		dispatchedAt = append(dispatchedAt, time.Now())
+		mu.Unlock()
+		// longer than minInterval, so the process calls overlap
+		time.Sleep(50 * time.Millisecond)
+		return in, nil
+	}, Options[int]{MinInterval: minInterval})
+
+	assert.Empty(t, errs)
+	assert.Len(t, outputs, len(inputs))
+	sort.Slice(dispatchedAt, func(i, j int) bool {
+		return dispatchedAt[i].Before(dispatchedAt[j])
+	})
+	for i := 1; i < len(dispatchedAt); i++ {
+		// with a little slack for the scheduling of the worker receiving the previous input
+		assert.GreaterOrEqual(t, dispatchedAt[i].Sub(dispatchedAt[i-1]), minInterval-2*time.Millisecond)
+	}
+}
+
+func TestExecuteWithOptionsMinIntervalCanceled(t *testing.T) {
+	// far longer than the test should take, so waiting for it out would fail the test
+	const minInterval = 2 * time.Second
+
+	for name, distribution := range map[string]DistributionStrategy{"shared queue": SharedQueue, "round robin": RoundRobin} {
+		t.Run(name, func(t *testing.T) {
+			start := time.Now()
+			assert.Panics(t, func() {
+				ExecuteWithOptions(2, []int{0, 1, 2, 3}, func(in int) (int, error) {
+					if in == 0 {
+						panic("unexpected input")
+					}
+					return in, nil
+				}, Options[int]{
+					MinInterval:  minInterval,
+					PanicPolicy:  PanicPropagate,
+					Distribution: distribution,
+				})
+			})
+			assert.Less(t, time.Since(start), minInterval/4)
+		})
+	}
+}
+
+func TestExecuteWithOptionsSkip(t *testing.T) {
+	inputs := make([]int, 100)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	var mu sync.Mutex
+	processed := []int{}
+	outputs, errs := ExecuteWithOptions(4, inputs, func(in int) (int, error) {
+		mu.Lock()
+		processed = append(processed, in)
+		mu.Unlock()
+		return in * 2, nil
+	}, Options[int]{Skip: func(in int) bool {
+		return in%2 == 1
+	}})
+
+	expected := []int{}
+	expectedOutputs := []int{}
+	for _, in := range inputs {
+		if in%2 == 0 {
+			expected = append(expected, in)
+			expectedOutputs = append(expectedOutputs, in*2)
+		}
+	}
+	assert.Empty(t, errs)
+	assert.ElementsMatch(t, expected, processed)
+	assert.ElementsMatch(t, expectedOutputs, outputs)
+}
+
+func TestExecuteWithOptionsDistribution(t *testing.T) {
+	// the first input is slow, so only the inputs assigned to its worker up front have to wait for it
+	slow := 50 * time.Millisecond
+	inputs := []int{0, 1, 2, 3, 4, 5, 6, 7}
+	weight := func(in int) int {
+		if in == 0 {
+			return 100
+		}
+		return 1
+	}
+
+	testCases := []struct {
+		name           string
+		opts           Options[int]
+		expectedWaited []int
+	}{
+		{
+			name:           "shared queue hands the other inputs to the free worker",
+			opts:           Options[int]{Distribution: SharedQueue},
+			expectedWaited: []int{},
+		},
+		{
+			name:           "round-robin assigns every other input to the slow worker",
+			opts:           Options[int]{Distribution: RoundRobin},
+			expectedWaited: []int{2, 4, 6},
+		},
+		{
+			name:           "weighted leaves the heavy input alone on its worker",
+			opts:           Options[int]{Distribution: Weighted, Weight: weight},
+			expectedWaited: []int{},
+		},
+		{
+			name:           "weighted without a weight falls back to shared queue",
+			opts:           Options[int]{Distribution: Weighted},
+			expectedWaited: []int{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var slowDone time.Time
+			startedAt := map[int]time.Time{}
+			outputs, errs := ExecuteWithOptions(2, inputs, func(in int) (int, error) {
+				mu.Lock()
+				startedAt[in] = time.Now()
+				mu.Unlock()
+				if in == 0 {
+					time.Sleep(slow)
+					mu.Lock()
+					slowDone = time.Now()
+					mu.Unlock()
+				}
+				return in, nil
+			}, tc.opts)
+
+			assert.Empty(t, errs)
+			assert.ElementsMatch(t, inputs, outputs)
+			waited := []int{}
+			for _, in := range inputs {
+				if in != 0 && !startedAt[in].Before(slowDone) {
+					waited = append(waited, in)
+				}
+			}
+			assert.ElementsMatch(t, tc.expectedWaited, waited)
+		})
+	}
+}
+
+func TestExecuteWithOptionsWatchdogTimeout(t *testing.T) {
+	// captureLog returns everything logged through the log package while run is running.
+	captureLog := func(run func()) string {
+		var buf bytes.Buffer
+		writer, flags := log.Writer(), log.Flags()
+		log.SetOutput(&buf)
+		log.SetFlags(0)
+		defer func() {
+			log.SetOutput(writer)
+			log.SetFlags(flags)
+		}()
+		run()
+		return buf.String()
+	}
+
+	t.Run("a stall is logged without aborting the execution", func(t *testing.T) {
+		inputs := []int{0, 1, 2, 3, 4, 5}
+		release := make(chan struct{})
+		var outputs []int
+		logged := captureLog(func() {
+			time.AfterFunc(100*time.Millisecond, func() {
+				close(release)
+			})
+			outputs, _ = ExecuteWithOptions(2, inputs, func(in int) (int, error) {
+				// every worker is stuck on its first input until released
+				<-release
+				return in, nil
+			}, Options[int]{WatchdogTimeout: 20 * time.Millisecond})
+		})
+
+		assert.ElementsMatch(t, inputs, outputs)
+		assert.Contains(t, logged, "6 inputs pending, 2 workers stuck in process")
+	})
+
+	t.Run("nothing is logged while outputs keep being gathered", func(t *testing.T) {
+		inputs := make([]int, 20)
+		logged := captureLog(func() {
+			ExecuteWithOptions(2, inputs, func(in int) (int, error) {
+				time.Sleep(5 * time.Millisecond)
+				return in, nil
+			}, Options[int]{WatchdogTimeout: 50 * time.Millisecond})
+		})
+		assert.Empty(t, logged)
+	})
+}
+
+func TestExecuteWithOptionsLabel(t *testing.T) {
+	// goroutineDump returns a goroutine profile, which lists the pprof labels of every goroutine.
+	goroutineDump := func() string {
+		var buf bytes.Buffer
+		assert.NoError(t, pprof.Lookup("goroutine").WriteTo(&buf, 1))
+		return buf.String()
+	}
+
+	// dumpWhileBusy returns the goroutine dump taken while the workers of an execution with the label are busy.
+	dumpWhileBusy := func(label string) string {
+		release := make(chan struct{})
+		dumps := make(chan string, 1)
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			dumps <- goroutineDump()
+			close(release)
+		}()
+
+		ExecuteWithOptions(2, []int{1, 2}, func(in int) (int, error) {
+			<-release
+			return in, nil
+		}, Options[int]{Label: label})
+		return <-dumps
+	}
+
+	t.Run("the workers are tagged with the label", func(t *testing.T) {
+		assert.Contains(t, dumpWhileBusy("resize-images"), `"concurrent":"resize-images"`)
+	})
+
+	t.Run("no label is set without a Label", func(t *testing.T) {
+		assert.NotContains(t, dumpWhileBusy(""), `"concurrent":`)
+	})
+}
+
+func benchmarkBufferSize(b *testing.B, bufferSize int) {
+	inputs := make([]int, 100000)
+	increment := func(in int) (int, error) {
+		return in + 1, nil
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ExecuteWithOptions(8, inputs, increment, Options[int]{BufferSize: bufferSize})
+	}
+}
+
+func BenchmarkExecuteWithOptionsUnbuffered(b *testing.B) {
+	benchmarkBufferSize(b, -1)
+}
+
+func BenchmarkExecuteWithOptionsDefaultBuffer(b *testing.B) {
+	benchmarkBufferSize(b, 0)
+}
+
+func BenchmarkExecuteWithOptionsLargeBuffer(b *testing.B) {
+	benchmarkBufferSize(b, 1024)
+}
+
+func benchmarkDistribution(b *testing.B, inputs []time.Duration, opts Options[time.Duration]) {
+	process := func(in time.Duration) (time.Duration, error) {
+		return sleepFor(in), nil
+	}
+	for i := 0; i < b.N; i++ {
+		ExecuteWithOptions(4, inputs, process, opts)
+	}
+}
+
+// uniformInputs returns as many inputs as skewedInputs, with the same total duration spread evenly.
+func uniformInputs() []time.Duration {
+	inputs := make([]time.Duration, 40)
+	for i := range inputs {
+		inputs[i] = 12500 * time.Microsecond
+	}
+	return inputs
+}
+
+func BenchmarkDistributionUniformSharedQueue(b *testing.B) {
+	benchmarkDistribution(b, uniformInputs(), Options[time.Duration]{Distribution: SharedQueue})
+}
+
+func BenchmarkDistributionUniformRoundRobin(b *testing.B) {
+	benchmarkDistribution(b, uniformInputs(), Options[time.Duration]{Distribution: RoundRobin})
+}
+
+func BenchmarkDistributionUniformWeighted(b *testing.B) {
+	benchmarkDistribution(b, uniformInputs(), Options[time.Duration]{Distribution: Weighted, Weight: durationWeight})
+}
+
+func BenchmarkDistributionSkewedSharedQueue(b *testing.B) {
+	benchmarkDistribution(b, skewedInputs(), Options[time.Duration]{Distribution: SharedQueue})
+}
+
+func BenchmarkDistributionSkewedRoundRobin(b *testing.B) {
+	benchmarkDistribution(b, skewedInputs(), Options[time.Duration]{Distribution: RoundRobin})
+}
+
+func BenchmarkDistributionSkewedWeighted(b *testing.B) {
+	benchmarkDistribution(b, skewedInputs(), Options[time.Duration]{Distribution: Weighted, Weight: durationWeight})
+}
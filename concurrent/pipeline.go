@@ -0,0 +1,53 @@
+package concurrent
+
+// Pipeline is a chain of concurrent stages, each of them processing the outputs of the previous one. A Pipeline is
+// started with NewPipeline, extended with Then, and run with Collect.
+//
+// Every stage runs its own workers, and all stages run at the same time once the pipeline is collected, so an output
+// of a stage is handed to the next stage as soon as it is processed. The stages are connected with unbuffered
+// channels, so a slow stage applies backpressure to all stages before it: their workers block on handing over their
+// outputs, and the pipeline as a whole is only as fast as its slowest stage, without ever piling up outputs in memory.
+type Pipeline[T any] struct {
+	start func() <-chan T
+}
+
+// NewPipeline returns a Pipeline whose first stage emits the inputs.
+func NewPipeline[T any](inputs []T) *Pipeline[T] {
+	return &Pipeline[T]{
+		start: func() <-chan T {
+			channel := make(chan T)
+			go func() {
+				defer close(channel)
+				for _, input := range inputs {
+					channel <- input
+				}
+			}()
+			return channel
+		},
+	}
+}
+
+// Then returns a Pipeline that extends p with a stage processing every output of p by calling the process function,
+// using numOfRoutines goroutines. Like Execute, a stage does not keep the order of its inputs. Then is a function
+// instead of a method of Pipeline, because Go methods could not introduce the type parameter of the stage's outputs.
+func Then[A any, B any](p *Pipeline[A], numOfRoutines int, process func(input A) B) *Pipeline[B] {
+	if process == nil {
+		panic(ErrNilProcess)
+	}
+
+	return &Pipeline[B]{
+		start: func() <-chan B {
+			return ExecuteStream(numOfRoutines, p.start(), process)
+		},
+	}
+}
+
+// Collect runs every stage of the pipeline and returns the outputs of the last stage. Nothing is processed until
+// Collect is called, and every call runs the whole pipeline again.
+func (p *Pipeline[T]) Collect() []T {
+	outputs := []T{}
+	for o := range p.start() {
+		outputs = append(outputs, o)
+	}
+	return outputs
+}
@@ -0,0 +1,38 @@
+package concurrent_test
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline(t *testing.T) {
+	inputs := make([]int, 100)
+	expectedOutputs := []string{}
+	for i := range inputs {
+		inputs[i] = i
+		expectedOutputs = append(expectedOutputs, strconv.Itoa(i*2+1))
+	}
+
+	var calls int64
+	doubled := Then(NewPipeline(inputs), 4, func(in int) int {
+		atomic.AddInt64(&calls, 1)
+		return in * 2
+	})
+	incremented := Then(doubled, 2, func(in int) int {
+		return in + 1
+	})
+	formatted := Then(incremented, 3, strconv.Itoa)
+	assert.Equal(t, int64(0), atomic.LoadInt64(&calls))
+
+	assert.ElementsMatch(t, expectedOutputs, formatted.Collect())
+	assert.Equal(t, int64(len(inputs)), atomic.LoadInt64(&calls))
+}
+
+func TestPipelineWithoutStages(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3}, NewPipeline([]int{1, 2, 3}).Collect())
+	assert.Empty(t, NewPipeline([]int{}).Collect())
+}
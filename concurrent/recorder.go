@@ -0,0 +1,66 @@
+package concurrent
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Recorder observes how long every process call takes, e.g. to export the latencies as a histogram. Observe is called
+// by the workers, right after every process call returns, so it could be called concurrently by multiple workers, and
+// the implementation must do its own synchronization.
+type Recorder interface {
+	Observe(d time.Duration)
+}
+
+// BucketedRecorder is a Recorder counting the observed durations into buckets delimited by upper bounds, like the
+// buckets of a histogram. A BucketedRecorder must be created with NewBucketedRecorder, and is safe for concurrent use.
+type BucketedRecorder struct {
+	bounds []time.Duration
+
+	mu     sync.Mutex
+	counts []int
+}
+
+// NewBucketedRecorder returns a BucketedRecorder with a bucket for every bound, counting the durations up to and
+// including that bound that do not fit any lower one, and a last bucket counting the durations above every bound. The
+// bounds do not need to be sorted.
+func NewBucketedRecorder(bounds ...time.Duration) *BucketedRecorder {
+	sorted := make([]time.Duration, len(bounds))
+	copy(sorted, bounds)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	return &BucketedRecorder{
+		bounds: sorted,
+		counts: make([]int, len(sorted)+1),
+	}
+}
+
+// Observe counts d into its bucket.
+func (r *BucketedRecorder) Observe(d time.Duration) {
+	bucket := sort.Search(len(r.bounds), func(i int) bool {
+		return d <= r.bounds[i]
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counts[bucket]++
+}
+
+// Bounds returns the upper bounds of the buckets, sorted in increasing order.
+func (r *BucketedRecorder) Bounds() []time.Duration {
+	bounds := make([]time.Duration, len(r.bounds))
+	copy(bounds, r.bounds)
+	return bounds
+}
+
+// Counts returns the number of durations observed so far in every bucket, in the order of Bounds, followed by the
+// number of durations above every bound.
+func (r *BucketedRecorder) Counts() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := make([]int, len(r.counts))
+	copy(counts, r.counts)
+	return counts
+}
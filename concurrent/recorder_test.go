@@ -0,0 +1,36 @@
+package concurrent_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketedRecorder(t *testing.T) {
+	r := NewBucketedRecorder(10*time.Millisecond, time.Millisecond, 100*time.Millisecond)
+	assert.Equal(t, []time.Duration{time.Millisecond, 10 * time.Millisecond, 100 * time.Millisecond}, r.Bounds())
+
+	for _, d := range []time.Duration{0, time.Millisecond, 2 * time.Millisecond, 10 * time.Millisecond, 50 * time.Millisecond, time.Second} {
+		r.Observe(d)
+	}
+	assert.Equal(t, []int{2, 2, 1, 1}, r.Counts())
+}
+
+func TestExecuteWithOptionsRecorder(t *testing.T) {
+	inputs := make([]time.Duration, 20)
+	for i := range inputs {
+		if i%4 == 0 {
+			inputs[i] = 20 * time.Millisecond
+		}
+	}
+
+	r := NewBucketedRecorder(10 * time.Millisecond)
+	_, errs := ExecuteWithOptions(4, inputs, func(in time.Duration) (time.Duration, error) {
+		return sleepFor(in), nil
+	}, Options[time.Duration]{Recorder: r})
+
+	assert.Empty(t, errs)
+	assert.Equal(t, []int{15, 5}, r.Counts())
+}
@@ -0,0 +1,14 @@
+package concurrent
+
+// ReduceStream folds every value received from the input channel into a single accumulator using the combiner
+// function, starting from identity, and returns it once the input channel is closed. The values are folded one at a
+// time as they arrive, so like ExecuteStream, whose output it could consume, it never holds more than the accumulator,
+// which keeps the aggregation of an unbounded stream memory-bounded. Unlike ExecuteReduce, the combiner is only called
+// from the calling goroutine and in the order the values are received, so it needs not be associative nor commutative.
+func ReduceStream[TypeOut any, Acc any](input <-chan TypeOut, identity Acc, combiner func(acc Acc, output TypeOut) Acc) Acc {
+	acc := identity
+	for output := range input {
+		acc = combiner(acc, output)
+	}
+	return acc
+}
@@ -0,0 +1,41 @@
+package concurrent_test
+
+import (
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReduceStream(t *testing.T) {
+	t.Run("a stream of a million integers is summed", func(t *testing.T) {
+		const n = 1000000
+		input := make(chan int)
+		go func() {
+			defer close(input)
+			for i := 1; i <= n; i++ {
+				input <- i
+			}
+		}()
+
+		total := ReduceStream(ExecuteStream(4, input, func(in int) int64 {
+			return int64(in)
+		}), int64(0), func(acc int64, output int64) int64 {
+			return acc + output
+		})
+		assert.Equal(t, int64(n)*(n+1)/2, total)
+	})
+
+	t.Run("the values are folded in the order they are received", func(t *testing.T) {
+		joined := ReduceStream(generate("a", "b", "c"), "", func(acc string, output string) string {
+			return acc + output
+		})
+		assert.Equal(t, "abc", joined)
+	})
+
+	t.Run("a closed empty stream returns identity", func(t *testing.T) {
+		assert.Equal(t, 42, ReduceStream(generate[int](), 42, func(acc int, output int) int {
+			return acc + output
+		}))
+	})
+}
@@ -0,0 +1,21 @@
+package concurrent
+
+// Scatter distributes the values received from the input channel over numOfRoutines output channels, and closes all
+// of them once the input channel is closed. Every output channel is fed by its own goroutine that reads the next value
+// from the input channel only after the previous one has been received from its output channel, so the values are
+// balanced by how fast every output channel is consumed rather than assigned round-robin.
+func Scatter[T any](numOfRoutines int, input <-chan T) []<-chan T {
+	numOfRoutines = clampRoutines(positiveOrDefault(numOfRoutines))
+	outputChannels := make([]<-chan T, numOfRoutines)
+	for i := range outputChannels {
+		outputChannel := make(chan T)
+		outputChannels[i] = outputChannel
+		go func() {
+			defer close(outputChannel)
+			for v := range input {
+				outputChannel <- v
+			}
+		}()
+	}
+	return outputChannels
+}
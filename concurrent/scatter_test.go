@@ -0,0 +1,29 @@
+package concurrent_test
+
+import (
+	"testing"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScatter(t *testing.T) {
+	t.Run("every value goes to exactly one output", func(t *testing.T) {
+		values := make([]int, 100)
+		for i := range values {
+			values[i] = i
+		}
+
+		outputs := Scatter(3, generate(values...))
+		assert.Len(t, outputs, 3)
+		assert.ElementsMatch(t, values, drain(Merge(outputs...)))
+	})
+
+	t.Run("empty input closes every output", func(t *testing.T) {
+		outputs := Scatter(4, generate[int]())
+		assert.Len(t, outputs, 4)
+		for _, output := range outputs {
+			assert.Empty(t, drain(output))
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package concurrent
+
+// Semaphore bounds the number of goroutines that could run a piece of code at the same time, for the cases that do not
+// fit the slices of inputs and outputs of Execute. A Semaphore must be created with NewSemaphore.
+type Semaphore struct {
+	slots chan struct{}
+}
+
+// NewSemaphore returns a Semaphore with n slots. Like numOfRoutines, an n that is zero or negative defaults to
+// DefaultRoutines, but unlike numOfRoutines, n is not clamped to MaxRoutines, since the slots are not goroutines.
+func NewSemaphore(n int) *Semaphore {
+	return &Semaphore{slots: make(chan struct{}, positiveOrDefault(n))}
+}
+
+// Acquire takes a slot of the semaphore, blocking until one is free.
+func (s *Semaphore) Acquire() {
+	s.slots <- struct{}{}
+}
+
+// TryAcquire takes a slot of the semaphore if one is free, and reports whether it did without blocking.
+func (s *Semaphore) TryAcquire() bool {
+	select {
+	case s.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Release frees a slot taken by Acquire or TryAcquire. It panics when there is no slot to free.
+func (s *Semaphore) Release() {
+	select {
+	case <-s.slots:
+	default:
+		panic("concurrent: Release called without a matching Acquire")
+	}
+}
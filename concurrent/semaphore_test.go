@@ -0,0 +1,59 @@
+package concurrent_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSemaphore(t *testing.T) {
+	t.Run("bounds the number of concurrent holders", func(t *testing.T) {
+		sem := NewSemaphore(3)
+		var counter peakCounter
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem.Acquire()
+				defer sem.Release()
+
+				counter.enter()
+				defer counter.exit()
+				time.Sleep(time.Millisecond)
+			}()
+		}
+		wg.Wait()
+		assert.LessOrEqual(t, counter.max(), int64(3))
+	})
+
+	t.Run("try acquire does not block when full", func(t *testing.T) {
+		sem := NewSemaphore(1)
+		assert.True(t, sem.TryAcquire())
+		assert.False(t, sem.TryAcquire())
+		sem.Release()
+		assert.True(t, sem.TryAcquire())
+	})
+
+	t.Run("the slots are not clamped to MaxRoutines", func(t *testing.T) {
+		maxRoutines := MaxRoutines
+		defer func() {
+			MaxRoutines = maxRoutines
+		}()
+
+		MaxRoutines = 2
+		sem := NewSemaphore(5)
+		for i := 0; i < 5; i++ {
+			assert.True(t, sem.TryAcquire())
+		}
+		assert.False(t, sem.TryAcquire())
+	})
+
+	t.Run("release without acquire panics", func(t *testing.T) {
+		sem := NewSemaphore(1)
+		assert.Panics(t, sem.Release)
+	})
+}
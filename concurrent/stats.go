@@ -0,0 +1,67 @@
+package concurrent
+
+import "sync/atomic"
+
+// Stats reports how far an execution that could stop early went through its inputs, so that the caller could tell
+// which part of them is left to retry.
+type Stats struct {
+	// Processed is the number of inputs the process function was called with, including the failed ones.
+	Processed int
+	// Skipped is the number of inputs that were never handed to the process function because the execution stopped
+	// early. Processed and Skipped always add up to the number of inputs.
+	Skipped int
+	// Failed is the number of processed inputs that contributed no output because they failed, as defined by every
+	// function returning Stats.
+	Failed int
+	// Unprocessed holds the indexes of the Skipped inputs, in increasing order, and is nil when no input was skipped.
+	// See UnprocessedInputs to get the inputs themselves, e.g. to requeue them.
+	Unprocessed []int
+}
+
+// UnprocessedInputs returns the inputs that were never handed to the process function, as reported by the Stats of an
+// execution over the same inputs, in the order of the inputs.
+func UnprocessedInputs[TypeIn any](inputs []TypeIn, stats Stats) []TypeIn {
+	unprocessed := make([]TypeIn, 0, len(stats.Unprocessed))
+	for _, index := range stats.Unprocessed {
+		unprocessed = append(unprocessed, inputs[index])
+	}
+	return unprocessed
+}
+
+// statsCounter counts the processed and failed inputs of an execution, from all of its workers at the same time.
+type statsCounter struct {
+	processed int64
+	failed    int64
+	// handed tells which inputs were handed to the process function, every index being set by a single worker
+	handed []bool
+}
+
+func newStatsCounter(numOfInputs int) *statsCounter {
+	return &statsCounter{handed: make([]bool, numOfInputs)}
+}
+
+func (c *statsCounter) process(index int) {
+	c.handed[index] = true
+	atomic.AddInt64(&c.processed, 1)
+}
+
+func (c *statsCounter) fail() {
+	atomic.AddInt64(&c.failed, 1)
+}
+
+// stats returns the Stats of the execution, once all of its workers have exited.
+func (c *statsCounter) stats() Stats {
+	processed := int(atomic.LoadInt64(&c.processed))
+	var unprocessed []int
+	for index, handed := range c.handed {
+		if !handed {
+			unprocessed = append(unprocessed, index)
+		}
+	}
+	return Stats{
+		Processed:   processed,
+		Skipped:     len(c.handed) - processed,
+		Failed:      int(atomic.LoadInt64(&c.failed)),
+		Unprocessed: unprocessed,
+	}
+}
@@ -0,0 +1,146 @@
+package concurrent_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	inputs := make([]int, 1000)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	t.Run("ExecuteContextWithStats", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var calls int64
+		outputs, stats, err := ExecuteContextWithStats(ctx, 4, inputs, func(_ context.Context, in int) int {
+			if atomic.AddInt64(&calls, 1) == 10 {
+				cancel()
+			}
+			return in
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, int(atomic.LoadInt64(&calls)), stats.Processed)
+		assert.Equal(t, len(inputs), stats.Processed+stats.Skipped)
+		assert.Equal(t, len(outputs), stats.Processed-stats.Failed)
+		assert.Positive(t, stats.Skipped)
+	})
+
+	t.Run("ExecuteUntilErrorWithStats", func(t *testing.T) {
+		errFail := errors.New("fail")
+		outputs, stats, err := ExecuteUntilErrorWithStats(4, inputs, func(in int) (int, error) {
+			if in == 10 {
+				return 0, errFail
+			}
+			return in, nil
+		})
+		assert.ErrorIs(t, err, errFail)
+		assert.Equal(t, 1, stats.Failed)
+		assert.Equal(t, len(inputs), stats.Processed+stats.Skipped)
+		assert.Equal(t, len(outputs), stats.Processed-stats.Failed)
+		assert.Positive(t, stats.Skipped)
+	})
+
+	t.Run("ExecuteNWithStats", func(t *testing.T) {
+		outputs, stats := ExecuteNWithStats(4, 5, inputs, func(in int) (int, bool) {
+			return in, true
+		})
+		assert.Len(t, outputs, 5)
+		assert.Zero(t, stats.Failed)
+		assert.GreaterOrEqual(t, stats.Processed, 5)
+		assert.Equal(t, len(inputs), stats.Processed+stats.Skipped)
+	})
+
+	t.Run("ExecuteWithDeadlineWithStats", func(t *testing.T) {
+		outputs, stats, completed := ExecuteWithDeadlineWithStats(20*time.Millisecond, 4, inputs, func(in int) int {
+			time.Sleep(time.Millisecond)
+			return in
+		})
+		assert.False(t, completed)
+		assert.Equal(t, len(inputs), stats.Processed+stats.Skipped)
+		assert.Equal(t, len(outputs), stats.Processed-stats.Failed)
+		assert.Positive(t, stats.Skipped)
+	})
+
+	t.Run("a complete execution skips nothing", func(t *testing.T) {
+		outputs, stats, err := ExecuteContextWithStats(context.Background(), 4, inputs, func(_ context.Context, in int) int {
+			return in
+		})
+		assert.NoError(t, err)
+		assert.Len(t, outputs, len(inputs))
+		assert.Equal(t, Stats{Processed: len(inputs)}, stats)
+	})
+}
+
+func TestUnprocessedInputs(t *testing.T) {
+	inputs := make([]int, 100)
+	for i := range inputs {
+		inputs[i] = i
+	}
+	errFail := errors.New("fail")
+	const failAt = 10
+
+	t.Run("a fast-fail on a single worker leaves the remaining inputs unprocessed", func(t *testing.T) {
+		var processed []int
+		_, stats, err := ExecuteUntilErrorWithStats(1, inputs, func(in int) (int, error) {
+			processed = append(processed, in)
+			if in == failAt {
+				return 0, errFail
+			}
+			return in, nil
+		})
+		assert.ErrorIs(t, err, errFail)
+		// the input right after the failed one may already be on its way to the worker when the execution stops
+		assert.Contains(t, []int{failAt + 1, failAt + 2}, len(processed))
+		assert.Equal(t, inputs[len(processed):], UnprocessedInputs(inputs, stats))
+		assert.Len(t, stats.Unprocessed, stats.Skipped)
+	})
+
+	t.Run("the unprocessed inputs are exactly the ones never handed to process", func(t *testing.T) {
+		var mu sync.Mutex
+		processed := map[int]bool{}
+		_, stats, _ := ExecuteUntilErrorWithStats(4, inputs, func(in int) (int, error) {
+			mu.Lock()
+			processed[in] = true
+			mu.Unlock()
+			if in == failAt {
+				return 0, errFail
+			}
+			return in, nil
+		})
+
+		expected := []int{}
+		for _, in := range inputs {
+			if !processed[in] {
+				expected = append(expected, in)
+			}
+		}
+		assert.NotEmpty(t, expected)
+		assert.Equal(t, expected, UnprocessedInputs(inputs, stats))
+	})
+
+	t.Run("ExecuteNWithStats with a non-positive n processes nothing", func(t *testing.T) {
+		_, stats := ExecuteNWithStats(4, 0, inputs, func(in int) (int, bool) {
+			return in, true
+		})
+		assert.Equal(t, inputs, UnprocessedInputs(inputs, stats))
+	})
+
+	t.Run("a complete execution leaves nothing unprocessed", func(t *testing.T) {
+		_, stats := ExecuteNWithStats(4, len(inputs), inputs, func(in int) (int, bool) {
+			return in, true
+		})
+		assert.Nil(t, stats.Unprocessed)
+		assert.Empty(t, UnprocessedInputs(inputs, stats))
+	})
+}
@@ -0,0 +1,31 @@
+package concurrent
+
+// Tee duplicates every value received from the input channel onto both of the returned channels, which are closed
+// once the input channel is closed. The two channels run in lock-step: the next value is only read from the input
+// channel after the current one has been received from both of them, in whichever order. So both consumers see every
+// value in the order of the input channel, without any value being buffered, but a slow consumer holds back the other
+// one, and a consumer that stops receiving blocks both. Wrap a returned channel with a buffered forwarder when one of
+// the consumers must be allowed to lag behind.
+func Tee[T any](input <-chan T) (<-chan T, <-chan T) {
+	first := make(chan T)
+	second := make(chan T)
+
+	go func() {
+		defer close(first)
+		defer close(second)
+		for v := range input {
+			// a channel that has received the value is set to nil, so the select only waits for the other one
+			first, second := first, second
+			for i := 0; i < 2; i++ {
+				select {
+				case first <- v:
+					first = nil
+				case second <- v:
+					second = nil
+				}
+			}
+		}
+	}()
+
+	return first, second
+}
@@ -0,0 +1,46 @@
+package concurrent_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTee(t *testing.T) {
+	t.Run("both branches receive every value in order", func(t *testing.T) {
+		values := make([]int, 100)
+		for i := range values {
+			values[i] = i
+		}
+
+		first, second := Tee(generate(values...))
+		var firstValues, secondValues []int
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			firstValues = drain(first)
+		}()
+		go func() {
+			defer wg.Done()
+			for v := range second {
+				// a slow branch only holds back the other one
+				time.Sleep(100 * time.Microsecond)
+				secondValues = append(secondValues, v)
+			}
+		}()
+		wg.Wait()
+
+		assert.Equal(t, values, firstValues)
+		assert.Equal(t, values, secondValues)
+	})
+
+	t.Run("empty input closes both branches", func(t *testing.T) {
+		first, second := Tee(generate[int]())
+		assert.Empty(t, drain(first))
+		assert.Empty(t, drain(second))
+	})
+}
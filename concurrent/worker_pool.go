@@ -0,0 +1,186 @@
+package concurrent
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// WorkerPool processes batches of inputs like Execute does, but with a fixed set of long-lived goroutines that are
+// reused across batches instead of being spawned on every call. A WorkerPool must be created with NewWorkerPool and
+// torn down with Shutdown or Close once it is no longer needed.
+//
+// Submit could be called sequentially as well as concurrently from multiple goroutines, in which case the inputs of
+// the concurrent batches share the same workers and every call still returns only the outputs of its own inputs.
+type WorkerPool[TypeIn any, TypeOut any] struct {
+	// queued, inFlight and completed are the counters of Stats, first in the struct to be aligned for the atomics
+	queued    int64
+	inFlight  int64
+	completed int64
+
+	process func(input TypeIn) TypeOut
+	jobs    chan poolJob[TypeIn, TypeOut]
+	wg      sync.WaitGroup
+
+	// mu guards closed, which is set once the pool stops accepting submissions, and the Add calls of submits, which
+	// tracks the Submit calls in progress.
+	mu           sync.Mutex
+	closed       bool
+	submits      sync.WaitGroup
+	shutdownOnce sync.Once
+	done         chan struct{}
+}
+
+// poolJob is a single input submitted to a WorkerPool, along with its index in the submitted inputs and the channel
+// its result should be sent to.
+type poolJob[TypeIn any, TypeOut any] struct {
+	input         TypeIn
+	index         int
+	outputChannel chan<- poolResult[TypeOut]
+}
+
+// poolResult is the result of a poolJob, where err is the *ItemError of a process call that panicked.
+type poolResult[TypeOut any] struct {
+	output TypeOut
+	err    error
+}
+
+// PoolStats is a snapshot of the inputs submitted to a WorkerPool, as returned by WorkerPool.Stats.
+type PoolStats struct {
+	// Queued is the number of submitted inputs that are waiting for a worker to pick them up.
+	Queued int
+	// InFlight is the number of inputs that are being processed by a worker, which is at most the number of workers.
+	InFlight int
+	// Completed is the number of inputs whose process call has returned, or panicked, since the pool was created.
+	Completed int
+}
+
+// NewWorkerPool spawns numOfRoutines goroutines that process every input submitted to the returned WorkerPool by
+// calling the process function.
+func NewWorkerPool[TypeIn any, TypeOut any](numOfRoutines int, process func(input TypeIn) TypeOut) *WorkerPool[TypeIn, TypeOut] {
+	if process == nil {
+		panic(ErrNilProcess)
+	}
+
+	numOfRoutines = routinesOrDefault(numOfRoutines)
+	p := &WorkerPool[TypeIn, TypeOut]{
+		process: process,
+		jobs:    make(chan poolJob[TypeIn, TypeOut]),
+		done:    make(chan struct{}),
+	}
+
+	p.wg.Add(numOfRoutines)
+	for i := 0; i < numOfRoutines; i++ {
+		go p.work()
+	}
+
+	return p
+}
+
+func (p *WorkerPool[TypeIn, TypeOut]) work() {
+	defer p.wg.Done()
+	process := func(input TypeIn) (TypeOut, error) {
+		return p.process(input), nil
+	}
+	for job := range p.jobs {
+		atomic.AddInt64(&p.queued, -1)
+		atomic.AddInt64(&p.inFlight, 1)
+		// a panic is recovered, so the worker goes on with the next job instead of taking the whole program down
+		output, err := protect(process, job.input)
+		atomic.AddInt64(&p.inFlight, -1)
+		atomic.AddInt64(&p.completed, 1)
+		if err != nil {
+			err = &ItemError{Index: job.index, Err: err}
+		}
+		job.outputChannel <- poolResult[TypeOut]{output: output, err: err}
+	}
+}
+
+// Submit processes all inputs with the workers of the pool and blocks until every output is gathered. Like Execute,
+// the output slice is not guaranteed to have the same order as the inputs. An input whose process call panics is left
+// out of the outputs, and the worker that processed it stays in the pool, see SubmitWithError to tell which inputs
+// panicked. Submit panics when it is called after the pool has started shutting down.
+func (p *WorkerPool[TypeIn, TypeOut]) Submit(inputs []TypeIn) []TypeOut {
+	outputs, _ := p.SubmitWithError(inputs)
+	return outputs
+}
+
+// SubmitWithError works like Submit, but every input whose process call panicked is also reported among the returned
+// errors, as an *ItemError wrapping the *PanicError of the panic.
+func (p *WorkerPool[TypeIn, TypeOut]) SubmitWithError(inputs []TypeIn) ([]TypeOut, []error) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		panic("concurrent: Submit called on a WorkerPool that is shut down")
+	}
+	p.submits.Add(1)
+	p.mu.Unlock()
+	defer p.submits.Done()
+
+	outputChannel := make(chan poolResult[TypeOut])
+	atomic.AddInt64(&p.queued, int64(len(inputs)))
+
+	// distribute inputs
+	go func() {
+		for i, input := range inputs {
+			p.jobs <- poolJob[TypeIn, TypeOut]{input: input, index: i, outputChannel: outputChannel}
+		}
+	}()
+
+	// wait for outputs
+	outputs := make([]TypeOut, 0, len(inputs))
+	errs := []error{}
+	for range inputs {
+		r := <-outputChannel
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		outputs = append(outputs, r.output)
+	}
+
+	return outputs, errs
+}
+
+// Stats returns the number of inputs that are queued, in flight and completed in the pool, across all the Submit calls.
+// It could be called at any time, including while the pool is busy, e.g. from a health endpoint. Every counter is read
+// atomically on its own, so an input moving from one counter to the next at the same time could be seen in both or in
+// neither of them.
+func (p *WorkerPool[TypeIn, TypeOut]) Stats() PoolStats {
+	return PoolStats{
+		Queued:    int(atomic.LoadInt64(&p.queued)),
+		InFlight:  int(atomic.LoadInt64(&p.inFlight)),
+		Completed: int(atomic.LoadInt64(&p.completed)),
+	}
+}
+
+// Shutdown stops the pool from accepting new submissions, waits for the Submit calls in progress to return, and then
+// stops the workers and waits for them to exit. When ctx is done before all of that happens, Shutdown returns ctx.Err()
+// without waiting any further, while the pool keeps shutting down in the background. Shutdown could be called more
+// than once, and every call waits for the same shutdown.
+func (p *WorkerPool[TypeIn, TypeOut]) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+
+	p.shutdownOnce.Do(func() {
+		go func() {
+			p.submits.Wait()
+			close(p.jobs)
+			p.wg.Wait()
+			close(p.done)
+		}()
+	})
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close shuts the pool down like Shutdown does, but without a deadline, i.e. it waits for as long as it takes.
+func (p *WorkerPool[TypeIn, TypeOut]) Close() {
+	_ = p.Shutdown(context.Background())
+}
@@ -0,0 +1,169 @@
+package concurrent_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/raymondhartoyo/gorutin/concurrent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPool(t *testing.T) {
+	inputs := []testInput{
+		{value: "value1"},
+		{value: "value2"},
+		{value: valueForErrorCase},
+		{value: "value3"},
+		{value: "value4"},
+	}
+	expectedOutputs := []testOutput{}
+	for _, i := range inputs {
+		expectedOutputs = append(expectedOutputs, testProcess(i))
+	}
+
+	t.Run("sequential submits reuse the same workers", func(t *testing.T) {
+		goroutinesBefore := runtime.NumGoroutine()
+		pool := NewWorkerPool(3, testProcess)
+		for i := 0; i < 3; i++ {
+			assert.ElementsMatch(t, expectedOutputs, pool.Submit(inputs))
+		}
+		assert.ElementsMatch(t, []testOutput{}, pool.Submit([]testInput{}))
+		pool.Close()
+		assertNoGoroutineLeak(t, goroutinesBefore)
+	})
+
+	t.Run("concurrent submits only return their own outputs", func(t *testing.T) {
+		pool := NewWorkerPool(2, func(in int) int {
+			return in * 10
+		})
+		defer pool.Close()
+
+		var wg sync.WaitGroup
+		for i := 1; i <= 5; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				outputs := pool.Submit([]int{i, i, i})
+				assert.Equal(t, []int{i * 10, i * 10, i * 10}, outputs)
+			}(i)
+		}
+		wg.Wait()
+	})
+}
+
+func TestWorkerPoolPanic(t *testing.T) {
+	const numOfRoutines = 3
+	var counter peakCounter
+	pool := NewWorkerPool(numOfRoutines, func(in int) int {
+		if in < 0 {
+			panic("negative input")
+		}
+		counter.enter()
+		defer counter.exit()
+		time.Sleep(5 * time.Millisecond)
+		return in
+	})
+	defer pool.Close()
+
+	outputs, errs := pool.SubmitWithError([]int{1, -1, 2, -2})
+	assert.ElementsMatch(t, []int{1, 2}, outputs)
+	indexes := []int{}
+	for _, err := range errs {
+		var itemErr *ItemError
+		var panicErr *PanicError
+		if assert.True(t, errors.As(err, &itemErr)) {
+			indexes = append(indexes, itemErr.Index)
+		}
+		assert.True(t, errors.As(err, &panicErr))
+	}
+	assert.ElementsMatch(t, []int{1, 3}, indexes)
+
+	// every panicking input is left out of the outputs of Submit, while the pool keeps all of its workers
+	assert.ElementsMatch(t, []int{3}, pool.Submit([]int{-3, 3}))
+	inputs := make([]int, 30)
+	assert.Len(t, pool.Submit(inputs), len(inputs))
+	assert.Equal(t, int64(numOfRoutines), counter.max())
+}
+
+// startedSleeping returns a process that sleeps for its input, and a channel that is closed once it is first called.
+func startedSleeping() (func(time.Duration) time.Duration, <-chan struct{}) {
+	started := make(chan struct{})
+	var once sync.Once
+	return func(d time.Duration) time.Duration {
+		once.Do(func() {
+			close(started)
+		})
+		return sleepFor(d)
+	}, started
+}
+
+func TestWorkerPoolShutdown(t *testing.T) {
+	t.Run("times out while slow work is in progress", func(t *testing.T) {
+		process, started := startedSleeping()
+		pool := NewWorkerPool(2, process)
+		go pool.Submit([]time.Duration{200 * time.Millisecond, 200 * time.Millisecond})
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, pool.Shutdown(ctx), context.DeadlineExceeded)
+		assert.Panics(t, func() {
+			pool.Submit([]time.Duration{0})
+		})
+		assert.NoError(t, pool.Shutdown(context.Background()))
+	})
+
+	t.Run("waits for the work in progress", func(t *testing.T) {
+		goroutinesBefore := runtime.NumGoroutine()
+		process, started := startedSleeping()
+		pool := NewWorkerPool(2, process)
+		outputs := make(chan []time.Duration, 1)
+		go func() {
+			outputs <- pool.Submit([]time.Duration{20 * time.Millisecond, 20 * time.Millisecond})
+		}()
+		<-started
+
+		assert.NoError(t, pool.Shutdown(context.Background()))
+		assert.Len(t, <-outputs, 2)
+		pool.Close()
+		assertNoGoroutineLeak(t, goroutinesBefore)
+	})
+}
+
+func TestWorkerPoolStats(t *testing.T) {
+	const numOfRoutines = 3
+	pool := NewWorkerPool(numOfRoutines, sleepFor)
+	defer pool.Close()
+	assert.Equal(t, PoolStats{}, pool.Stats())
+
+	inputs := make([]time.Duration, 10)
+	for i := range inputs {
+		inputs[i] = 20 * time.Millisecond
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pool.Submit(inputs)
+	}()
+
+	// poll until every worker is busy, at which point the rest of the inputs are queued
+	var stats PoolStats
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		stats = pool.Stats()
+		if stats.InFlight == numOfRoutines {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, numOfRoutines, stats.InFlight)
+	assert.Greater(t, stats.Queued, 0)
+	assert.LessOrEqual(t, stats.Queued+stats.InFlight+stats.Completed, len(inputs))
+
+	<-done
+	assert.Equal(t, PoolStats{Completed: len(inputs)}, pool.Stats())
+}
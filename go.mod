@@ -1,6 +1,6 @@
 module github.com/raymondhartoyo/gorutin
 
-go 1.18
+go 1.20
 
 require github.com/stretchr/testify v1.7.1
 